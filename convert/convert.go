@@ -1,14 +1,17 @@
 package convert
 
 import (
-	"errors"
 	"unicode/utf8"
 	"unsafe"
 
 	"github.com/bytedance/sonic"
+	"github.com/icloudza/gcjson/errs"
 )
 
-const MaxJSONSize = 10 << 20 // 10MB
+// MaxJSONSize 默认输入大小上限（10MB）。
+//
+// Deprecated: 上限改由 zeronode.Limits 统一配置（zeronode.SetLimits / GetLimits），本常量不再生效。
+const MaxJSONSize = 10 << 20
 
 //go:nosplit
 func UnsafeStringToBytes(s string) []byte {
@@ -23,31 +26,47 @@ func ValidUTF8(b []byte) bool {
 	return utf8.Valid(b)
 }
 
+// Source 可以直接提供 JSON 字节的输入，例如 zeronode.Node、zeronode.Partial
+type Source interface {
+	JSONBytes() []byte
+}
+
 func From(v any) ([]byte, error) {
 	switch x := v.(type) {
 	case nil:
-		return nil, errors.New("nil input")
+		return nil, errs.InvalidJSON("nil input")
 	case string:
 		b := UnsafeStringToBytes(x)
 		if !ValidUTF8(b) {
-			return nil, errors.New("invalid UTF-8 string")
+			return nil, errs.InvalidJSON("invalid UTF-8 string")
 		}
 		return b, nil
 	case []byte:
 		if !ValidUTF8(x) {
-			return nil, errors.New("invalid UTF-8 bytes")
+			return nil, errs.InvalidJSON("invalid UTF-8 bytes")
 		}
 		return x, nil
 	case *string:
 		if x == nil {
-			return nil, errors.New("nil string pointer")
+			return nil, errs.InvalidJSON("nil string pointer")
 		}
 		b := UnsafeStringToBytes(*x)
 		if !ValidUTF8(b) {
-			return nil, errors.New("invalid UTF-8 string")
+			return nil, errs.InvalidJSON("invalid UTF-8 string")
+		}
+		return b, nil
+	case Source:
+		b := x.JSONBytes()
+		if b == nil {
+			return nil, errs.InvalidJSON("empty json source")
 		}
 		return b, nil
 	default:
 		return sonic.ConfigStd.Marshal(v)
 	}
 }
+
+// Marshal 始终按 Go 值序列化（string 也会加引号），用于只序列化已取到的子树
+func Marshal(v any) ([]byte, error) {
+	return sonic.ConfigStd.Marshal(v)
+}
@@ -7,7 +7,11 @@
 //   - 支持数组、对象遍历，以及路径下钻（drill）。
 //
 // 输入类型：
-//   - 仅接受 []byte 或 string 类型作为输入。
+//   - JSON 文本：[]byte、string、*string。
+//   - 已解析的节点：zeronode.Node、zeronode.Partial（截断 JSON 的补全结果）。
+//     带注释的 JSONC / JSON5 配置先用 Parse 按对应模式得到节点再查询。
+//   - Go 值：map[string]any、[]any、结构体（按 json 名，同 encoding/json）及其指针，
+//     按路径直接下钻取值，不做整体序列化；路径含 gjson 语法时回退到序列化。
 //
 // 内部依赖：
 //   - 使用 gjson 进行路径解析与取值。
@@ -0,0 +1,41 @@
+package gcjson
+
+import (
+	"github.com/icloudza/gcjson/convert"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// DupPolicy 重复键策略，见 zeronode.DupPolicy
+type DupPolicy = zeronode.DupPolicy
+
+const (
+	DupFirst = zeronode.DupFirst // 第一个生效（默认）
+	DupLast  = zeronode.DupLast  // 最后一个生效，同 encoding/json
+	DupError = zeronode.DupError // 报 ErrDuplicateKey
+)
+
+// Duplicate FindDuplicates 报告的一个重复键
+type Duplicate = zeronode.Duplicate
+
+// SetDupPolicy 设置全局重复键策略，本包查询、raw、structfast 与 parser 的物化统一遵循。
+// 网关与后端对重复键取值不一致可被用于请求走私，对外入口建议设为 DupError。
+//
+//	gcjson.SetDupPolicy(gcjson.DupError)
+//	_, err := gcjson.Get[string](body, "user") // errors.Is(err, gcjson.ErrDuplicateKey)
+func SetDupPolicy(p DupPolicy) { zeronode.SetDupPolicy(p) }
+
+// GetDupPolicy 返回当前全局重复键策略
+func GetDupPolicy() DupPolicy { return zeronode.GetDupPolicy() }
+
+// FindDuplicates 列出输入中所有重复键及其路径与偏移，不受当前策略影响。
+// 输入类型同 Any；Go 值没有重复键，总是返回空。
+func FindDuplicates(v any) ([]Duplicate, error) {
+	if isStructCandidate(v) {
+		return nil, nil
+	}
+	b, err := convert.From(v)
+	if err != nil {
+		return nil, err
+	}
+	return zeronode.FindDuplicates(b), nil
+}
@@ -0,0 +1,97 @@
+// Package errs 定义 gcjson 各子包（根包、raw、structfast）共用的错误，
+// 可配合 errors.Is / errors.As 区分“路径不存在”“类型不符”“非法输入”“输入过大”“重复键”等。
+package errs
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrNotFound 路径不存在
+	ErrNotFound = errors.New("gcjson: path not found")
+	// ErrInvalidJSON 输入不是合法 JSON（含非法 UTF-8、nil 输入）
+	ErrInvalidJSON = errors.New("gcjson: invalid json")
+	// ErrTooLarge 输入超过大小上限
+	ErrTooLarge = errors.New("gcjson: json too large")
+	// ErrOutOfRange 数值超出目标类型的表示范围
+	ErrOutOfRange = errors.New("gcjson: value out of range")
+	// ErrDuplicateKey 对象中出现重复键（重复键策略为 DupError 时）
+	ErrDuplicateKey = errors.New("gcjson: duplicate key")
+	// ErrLimitExceeded 输入超出 zeronode.Limits 的某一项（嵌套层数、元素数、字符串长度等）
+	ErrLimitExceeded = errors.New("gcjson: limit exceeded")
+)
+
+// ErrTypeMismatch 路径上的值与目标类型不符。
+// Want 为目标 Go 类型，Got 为 JSON 类型（string/number/boolean/null/object/array）。
+type ErrTypeMismatch struct {
+	Path string
+	Want string
+	Got  string
+}
+
+func (e *ErrTypeMismatch) Error() string {
+	return "gcjson: cannot use " + e.Got + " as " + e.Want + " at " + strconv.Quote(e.Path)
+}
+
+// Is 使 errors.Is(err, &ErrTypeMismatch{}) 匹配任意类型不符错误；
+// 目标字段非空时要求对应字段相等。
+func (e *ErrTypeMismatch) Is(target error) bool {
+	t, ok := target.(*ErrTypeMismatch)
+	if !ok {
+		return false
+	}
+	return (t.Path == "" || t.Path == e.Path) &&
+		(t.Want == "" || t.Want == e.Want) &&
+		(t.Got == "" || t.Got == e.Got)
+}
+
+// NotFound 返回带路径信息、可用 errors.Is(err, ErrNotFound) 判断的错误
+func NotFound(path string) error {
+	return &wrapped{msg: "path " + strconv.Quote(path), err: ErrNotFound}
+}
+
+// InvalidJSON 返回带原因、可用 errors.Is(err, ErrInvalidJSON) 判断的错误
+func InvalidJSON(reason string) error { return &wrapped{msg: reason, err: ErrInvalidJSON} }
+
+// TooLarge 返回带大小信息、可用 errors.Is(err, ErrTooLarge) 判断的错误
+func TooLarge(size, limit int) error {
+	return &wrapped{msg: strconv.Itoa(size) + " bytes exceeds limit " + strconv.Itoa(limit), err: ErrTooLarge}
+}
+
+// OutOfRange 返回可用 errors.Is(err, ErrOutOfRange) 判断的溢出错误
+func OutOfRange(value, want string) error {
+	return &wrapped{msg: value + " overflows " + want, err: ErrOutOfRange}
+}
+
+// DuplicateKey 返回带路径与偏移、可用 errors.Is(err, ErrDuplicateKey) 判断的错误
+func DuplicateKey(path string, offset int) error {
+	return &wrapped{msg: strconv.Quote(path) + " at offset " + strconv.Itoa(offset), err: ErrDuplicateKey}
+}
+
+type wrapped struct {
+	msg string
+	err error
+}
+
+func (w *wrapped) Error() string { return w.err.Error() + ": " + w.msg }
+func (w *wrapped) Unwrap() error { return w.err }
+
+// JSONType 将 zeronode 节点类型字节转换为 JSON 类型名
+func JSONType(typ byte) string {
+	switch typ {
+	case 'o':
+		return "object"
+	case 'a':
+		return "array"
+	case 's':
+		return "string"
+	case 'n':
+		return "number"
+	case 'b':
+		return "boolean"
+	case 'l':
+		return "null"
+	}
+	return "missing"
+}
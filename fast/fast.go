@@ -3,7 +3,8 @@ package fast
 import (
 	"github.com/icloudza/gcjson/convert"
 	"github.com/tidwall/gjson"
-	"math"
+	"strconv"
+	"unsafe"
 )
 
 //go:nosplit
@@ -142,74 +143,99 @@ func GetTopKeyFast(b []byte, key string) (gjson.Result, bool) {
 	return gjson.Result{}, false
 }
 
-// ParseFloat 快速解析 JSON 数字（零分配）
-// 仅支持标准 JSON 格式，不支持 NaN/Inf
+// float64pow10 可被 float64 精确表示的 10 的幂
+var float64pow10 = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10,
+	1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22,
+}
+
+// ParseFloat 解析 JSON 数字（零分配），结果为正确舍入的最近 float64，与 strconv.ParseFloat 一致。
+// 尾数不超过 2^53 且十进制指数在 ±22 以内时走精确快路径（两个精确值做一次运算只舍入一次），
+// 其余交给 strconv.ParseFloat。仅支持标准 JSON 格式，不支持 NaN/Inf；溢出返回 false。
 func ParseFloat(b []byte) (float64, bool) {
-	if len(b) == 0 {
-		return 0, false
-	}
 	i := 0
 	neg := false
-	if b[i] == '-' {
+	if i < len(b) && b[i] == '-' {
 		neg = true
 		i++
 	}
 
-	// 整数部分
-	var intPart uint64
-	for i < len(b) {
-		c := b[i]
-		if c < '0' || c > '9' {
-			break
+	// 尾数：最多累计 19 位有效数字，超出则交给慢路径
+	var mant uint64
+	nd, exp10 := 0, 0
+	slow := false
+	start := i
+	for ; i < len(b) && b[i] >= '0' && b[i] <= '9'; i++ {
+		if mant == 0 && b[i] == '0' {
+			continue
 		}
-		intPart = intPart*10 + uint64(c-'0')
-		i++
+		if nd == 19 {
+			slow = true
+			continue
+		}
+		mant = mant*10 + uint64(b[i]-'0')
+		nd++
+	}
+	if i == start {
+		return 0, false
 	}
-
-	// 小数部分
-	var fracPart uint64
-	var fracDiv float64 = 1
 	if i < len(b) && b[i] == '.' {
 		i++
-		for i < len(b) {
-			c := b[i]
-			if c < '0' || c > '9' {
-				break
+		fs := i
+		for ; i < len(b) && b[i] >= '0' && b[i] <= '9'; i++ {
+			if mant == 0 && b[i] == '0' {
+				exp10--
+				continue
 			}
-			fracPart = fracPart*10 + uint64(c-'0')
-			fracDiv *= 10
-			i++
+			if nd == 19 {
+				slow = true
+				continue
+			}
+			mant = mant*10 + uint64(b[i]-'0')
+			nd++
+			exp10--
+		}
+		if i == fs {
+			return 0, false
 		}
 	}
-
-	// 指数部分
-	expSign := 1
-	expVal := 0
 	if i < len(b) && (b[i] == 'e' || b[i] == 'E') {
 		i++
-		if i < len(b) && b[i] == '+' {
-			i++
-		} else if i < len(b) && b[i] == '-' {
-			expSign = -1
+		esign := 1
+		if i < len(b) && (b[i] == '+' || b[i] == '-') {
+			if b[i] == '-' {
+				esign = -1
+			}
 			i++
 		}
-		for i < len(b) {
-			c := b[i]
-			if c < '0' || c > '9' {
-				break
+		es := i
+		e := 0
+		for ; i < len(b) && b[i] >= '0' && b[i] <= '9'; i++ {
+			if e < 100000 {
+				e = e*10 + int(b[i]-'0')
 			}
-			expVal = expVal*10 + int(c-'0')
-			i++
 		}
+		if i == es {
+			return 0, false
+		}
+		exp10 += esign * e
 	}
-
-	// 组合
-	f := float64(intPart) + float64(fracPart)/fracDiv
-	if expVal != 0 {
-		f *= math.Pow10(expSign * expVal)
+	if i != len(b) {
+		return 0, false
 	}
-	if neg {
-		f = -f
+
+	if !slow && mant <= 1<<53 && exp10 >= -22 && exp10 <= 22 {
+		f := float64(mant)
+		if exp10 < 0 {
+			f /= float64pow10[-exp10]
+		} else {
+			f *= float64pow10[exp10]
+		}
+		if neg {
+			f = -f
+		}
+		return f, true
 	}
-	return f, true
+	f, err := strconv.ParseFloat(unsafe.String(unsafe.SliceData(b), len(b)), 64)
+	return f, err == nil
 }
@@ -1,14 +1,13 @@
 package gcjson
 
 import (
-	"errors"
 	"github.com/icloudza/gcjson/cache"
 	"github.com/icloudza/gcjson/convert"
 	"github.com/icloudza/gcjson/fast"
 	"github.com/icloudza/gcjson/parser"
 	"github.com/icloudza/gcjson/picker"
 	"github.com/icloudza/gcjson/raw"
-	"github.com/icloudza/gcjson/structfast"
+	"github.com/icloudza/gcjson/zeronode"
 	"github.com/tidwall/gjson"
 )
 
@@ -30,13 +29,36 @@ func get(b []byte, path string) Result {
 	return gjson.GetBytes(b, path)
 }
 
+// checkInput 按 raw.SizeLimit（全局 Limits.MaxBytes）校验输入大小，并按全局重复键策略处理
+func checkInput(b []byte) ([]byte, error) {
+	if err := raw.CheckSize(len(b)); err != nil {
+		return nil, err
+	}
+	return dedupeInput(b)
+}
+
+// dedupeInput gjson 总是取第一个重复键：DupLast 时先去掉落选成员，DupError 时报 ErrDuplicateKey
+func dedupeInput(b []byte) ([]byte, error) {
+	if p := zeronode.GetDupPolicy(); p != zeronode.DupFirst {
+		return zeronode.Dedupe(b, p)
+	}
+	return b, nil
+}
+
 func GetAny(v any, path string) (gjson.Result, error) {
+	// Go 值输入：直接下钻，只序列化命中的子树
+	if x, found, handled := walkValue(v, path); handled {
+		if !found {
+			return gjson.Result{}, nil
+		}
+		return subtreeResult(x)
+	}
 	b, err := convert.From(v)
 	if err != nil {
 		return gjson.Result{}, err
 	}
-	if len(b) > convert.MaxJSONSize {
-		return gjson.Result{}, errors.New("json too large")
+	if b, err = checkInput(b); err != nil {
+		return gjson.Result{}, err
 	}
 	return get(b, path), nil
 }
@@ -47,8 +69,8 @@ func GetData(v any, path string) (gjson.Result, error) {
 	if err != nil {
 		return gjson.Result{}, err
 	}
-	if len(b) > convert.MaxJSONSize {
-		return gjson.Result{}, errors.New("json too large")
+	if b, err = checkInput(b); err != nil {
+		return gjson.Result{}, err
 	}
 	return get(b, path), nil
 }
@@ -59,14 +81,20 @@ func GetDataWithKeys(v any, keys []string, path string) (gjson.Result, error) {
 	if err != nil {
 		return gjson.Result{}, err
 	}
-	if len(b) > convert.MaxJSONSize {
-		return gjson.Result{}, errors.New("json too large")
+	if b, err = checkInput(b); err != nil {
+		return gjson.Result{}, err
 	}
 	return get(b, path), nil
 }
 
-// Any 自动类型推断
+// Any 自动类型推断；map、切片、结构体等 Go 值输入直接返回路径上的原值
 func Any(v any, path string) any {
+	if x, found, handled := walkValue(v, path); handled {
+		if !found {
+			return nil
+		}
+		return x
+	}
 	r, err := GetAny(v, path)
 	if err != nil || len(r.Raw) == 0 {
 		return nil
@@ -97,14 +125,19 @@ func AnyDataWithKeys(v any, keys []string, path string) any {
 	return parser.ToNative(r)
 }
 
+// AnyAs 取 path 处的值并转换为任意类型 T：各宽度整数/浮点数（带范围检查）、
+// time.Time、切片、map、结构体及其指针等复合类型经 structfast 解码，无需二次转换。
+// 需要失败原因时使用 Get。
 func AnyAs[T any](v any, path string) (T, bool) {
 	var zero T
 
-	// 结构体快路径：v 是 *struct 且 pathplan 是字段链
-	if isLikelyStructPath(path) {
-		if out, ok := structfast.GetByPtrTyped[T](v, path); ok { // 泛型快路径
-			return out, true
+	// Go 值快路径：*struct / map / slice 直接下钻，类型不符时按 JSON 语义转换该子树
+	if x, found, handled := walkValue(v, path); handled {
+		if !found {
+			return zero, false
 		}
+		out, err := goValueAs[T](x)
+		return out, err == nil
 	}
 
 	// JSON 回退
@@ -112,39 +145,8 @@ func AnyAs[T any](v any, path string) (T, bool) {
 	if err != nil || len(r.Raw) == 0 {
 		return zero, false
 	}
-	return parser.ToNativeTyped[T](r)
-}
-
-// 仅允许 A.B.C 这种导出字段链：A-Za-z0-9_，每段首字母必须大写（导出）
-func isLikelyStructPath(p string) bool {
-	if p == "" {
-		return false
-	}
-	start := 0
-	for i := 0; i <= len(p); i++ {
-		if i == len(p) || p[i] == '.' {
-			seg := p[start:i]
-			if len(seg) == 0 {
-				return false
-			}
-			// 首字母大写（导出）
-			c := seg[0]
-			if !(c >= 'A' && c <= 'Z') {
-				return false
-			}
-			// 余下只允许字母/数字/下划线
-			for j := 1; j < len(seg); j++ {
-				ch := seg[j]
-				isAlpha := (ch|0x20) >= 'a' && (ch|0x20) <= 'z'
-				isDigit := ch >= '0' && ch <= '9'
-				if !(isAlpha || isDigit || ch == '_') {
-					return false
-				}
-			}
-			start = i + 1
-		}
-	}
-	return true
+	out, err := resultAs[T](r)
+	return out, err == nil
 }
 
 type jtype byte
@@ -198,8 +200,8 @@ func AnyDataAs[T any](v any, path string) (T, bool) {
 	if err != nil || len(r.Raw) == 0 {
 		return zero, false
 	}
-	val, ok := parser.ToNative(r).(T)
-	return val, ok
+	val, err := resultAs[T](r)
+	return val, err == nil
 }
 
 // AnyAsFast 泛型快路径（零分配常见类型）
@@ -226,14 +228,17 @@ func AnyAsFast[T any](v any, path string) (T, bool) {
 		if vw.kind == jtFalse {
 			return any(false).(T), true
 		}
-	case int, int64:
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
 		if vw.kind == jtNumber && parser.IsIntegerLiteralBytes(vw.raw) {
-			if i64, ok := parser.ParseIntFastBytes(vw.raw); ok {
-				if _, ok2 := any(zero).(int); ok2 {
-					return any(int(i64)).(T), true
-				}
-				return any(i64).(T), true
+			// 带范围检查：超出目标类型范围视为失败，不做截断
+			return parser.IntegerBytesAs[T](vw.raw)
+		}
+	case float32:
+		if vw.kind == jtNumber {
+			if f, ok := parser.ParseFloat32Bytes(vw.raw); ok {
+				return any(f).(T), true
 			}
+			return zero, false
 		}
 	case float64:
 		if vw.kind == jtNumber {
@@ -247,11 +252,9 @@ func AnyAsFast[T any](v any, path string) (T, bool) {
 		}
 	}
 
-	// 复杂类型 or 类型不匹配 → 回退
-	if out, ok := parser.ToNative(r).(T); ok {
-		return out, true
-	}
-	return zero, false
+	// 复杂类型 or 类型不匹配 → 回退到通用转换
+	out, err := resultAs[T](r)
+	return out, err == nil
 }
 
 func AnyOrAsFast[T any](v any, path string, def T) T {
@@ -301,8 +304,8 @@ func AnyDataAsFast[T any](v any, path string) (T, bool) {
 			}
 		}
 	}
-	val, ok := parser.ToNative(r).(T)
-	return val, ok
+	val, err := resultAs[T](r)
+	return val, err == nil
 }
 
 // TypeOfAny 类型检测
@@ -332,17 +335,11 @@ func TypeOfAny(v any, path string) string {
 }
 
 func MapAny(v any, path string) map[string]any {
-	if m, ok := Any(v, path).(map[string]any); ok {
-		return m
-	}
-	return nil
+	return MapAnyWith(v, path, NativeOptions{})
 }
 
 func ArrayAny(v any, path string) []any {
-	if a, ok := Any(v, path).([]any); ok {
-		return a
-	}
-	return nil
+	return ArrayAnyWith(v, path, NativeOptions{})
 }
 
 func AnyMany(v any, paths ...string) ([]any, error) {
@@ -350,6 +347,9 @@ func AnyMany(v any, paths ...string) ([]any, error) {
 	if err != nil {
 		return nil, err
 	}
+	if b, err = checkInput(b); err != nil {
+		return nil, err
+	}
 	results := gjson.GetManyBytes(b, paths...)
 	out := make([]any, len(results))
 	for i, r := range results {
@@ -364,12 +364,8 @@ func AnyMany(v any, paths ...string) ([]any, error) {
 
 // EachObject 迭代器 API
 func EachObject(v any, path string, fn func(k string, r gjson.Result) bool) bool {
-	b, err := convert.From(v)
-	if err != nil {
-		return false
-	}
-	r := gjson.GetBytes(b, path)
-	if len(r.Raw) == 0 {
+	_, r, found := lookup(v, path)
+	if !found || len(r.Raw) == 0 {
 		return false
 	}
 	ok := false
@@ -378,12 +374,8 @@ func EachObject(v any, path string, fn func(k string, r gjson.Result) bool) bool
 }
 
 func EachArray(v any, path string, fn func(i int, r gjson.Result) bool) bool {
-	b, err := convert.From(v)
-	if err != nil {
-		return false
-	}
-	r := gjson.GetBytes(b, path)
-	if len(r.Raw) == 0 {
+	_, r, ok := lookup(v, path)
+	if !ok || len(r.Raw) == 0 {
 		return false
 	}
 	i := 0
@@ -392,12 +384,8 @@ func EachArray(v any, path string, fn func(i int, r gjson.Result) bool) bool {
 }
 
 func EachObjectBytes(v any, path string, fn func(keyBytes []byte, val gjson.Result) bool) bool {
-	b, err := convert.From(v)
-	if err != nil {
-		return false
-	}
-	r := gjson.GetBytes(b, path)
-	if len(r.Raw) == 0 {
+	b, r, found := lookup(v, path)
+	if !found || len(r.Raw) == 0 {
 		return false
 	}
 	hit := false
@@ -416,12 +404,8 @@ func EachObjectBytes(v any, path string, fn func(keyBytes []byte, val gjson.Resu
 }
 
 func EachArrayZero(v any, path string, fn func(i int, r gjson.Result) bool) bool {
-	b, err := convert.From(v)
-	if err != nil {
-		return false
-	}
-	r := gjson.GetBytes(b, path)
-	if len(r.Raw) == 0 {
+	_, r, ok := lookup(v, path)
+	if !ok || len(r.Raw) == 0 {
 		return false
 	}
 	i := 0
@@ -430,12 +414,8 @@ func EachArrayZero(v any, path string, fn func(i int, r gjson.Result) bool) bool
 }
 
 func ForEachArrayResult(v any, path string, fn func(idx int, r gjson.Result) bool) bool {
-	b, err := convert.From(v)
-	if err != nil {
-		return false
-	}
-	r := gjson.GetBytes(b, path)
-	if len(r.Raw) == 0 {
+	_, r, ok := lookup(v, path)
+	if !ok || len(r.Raw) == 0 {
 		return false
 	}
 	i := 0
@@ -462,12 +442,8 @@ func ForEachDataArrayResult(v any, path string, fn func(idx int, r gjson.Result)
 }
 
 func ForEachObjectResult(v any, path string, fn func(key string, r gjson.Result) bool) bool {
-	b, err := convert.From(v)
-	if err != nil {
-		return false
-	}
-	r := gjson.GetBytes(b, path)
-	if len(r.Raw) == 0 {
+	_, r, ok := lookup(v, path)
+	if !ok || len(r.Raw) == 0 {
 		return false
 	}
 	count := 0
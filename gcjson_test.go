@@ -0,0 +1,644 @@
+package gcjson
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/icloudza/gcjson/errs"
+	"github.com/icloudza/gcjson/parser"
+	"github.com/icloudza/gcjson/raw"
+	"github.com/icloudza/gcjson/structfast"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+type anyAsDoc struct {
+	Data struct {
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+		Items []struct {
+			SKU string `json:"sku"`
+		} `json:"items"`
+	} `json:"data"`
+}
+
+func TestAnyAsStructMatchesJSON(t *testing.T) {
+	raw := []byte(`{"data":{"user":{"name":"Alice"},"items":[{"sku":"A-1"}]}}`)
+	var doc anyAsDoc
+	doc.Data.User.Name = "Alice"
+	doc.Data.Items = append(doc.Data.Items, struct {
+		SKU string `json:"sku"`
+	}{SKU: "A-1"})
+
+	for _, p := range []string{"data.user.name", "data.items.0.sku"} {
+		fromJSON, ok1 := AnyAs[string](raw, p)
+		fromStruct, ok2 := AnyAs[string](&doc, p)
+		if !ok1 || !ok2 || fromJSON != fromStruct {
+			t.Fatalf("%s: json=%q(%v) struct=%q(%v)", p, fromJSON, ok1, fromStruct, ok2)
+		}
+	}
+}
+
+func TestGoValueInputs(t *testing.T) {
+	m := map[string]any{
+		"user":  map[string]any{"name": "Bob", "tags": []any{"a", "b"}},
+		"count": 3,
+	}
+	if got := Any(m, "user.name"); got != "Bob" {
+		t.Fatalf("Any = %v", got)
+	}
+	if got, ok := AnyAs[int](m, "count"); !ok || got != 3 {
+		t.Fatalf("AnyAs[int] = %v, %v", got, ok)
+	}
+	// 类型不符时按 JSON 语义转换
+	if got, ok := AnyAs[int64](m, "count"); !ok || got != 3 {
+		t.Fatalf("AnyAs[int64] = %v, %v", got, ok)
+	}
+	var tags []string
+	EachArray(m, "user.tags", func(i int, r Result) bool { tags = append(tags, r.String()); return true })
+	if len(tags) != 2 || tags[1] != "b" {
+		t.Fatalf("EachArray = %v", tags)
+	}
+
+	var doc anyAsDoc
+	doc.Data.User.Name = "Alice"
+	if u := MapAny(&doc, "data.user"); u["name"] != "Alice" {
+		t.Fatalf("MapAny = %v", u)
+	}
+	if Any(m, "user.missing") != nil {
+		t.Fatal("missing path should be nil")
+	}
+}
+
+type walkSecret struct{ v string }
+
+func (s walkSecret) MarshalJSON() ([]byte, error) { return []byte(`{"masked":true}`), nil }
+
+type walkUser struct {
+	Name     string     `json:"name"`
+	Password string     `json:"-"`
+	Nick     string     `json:"nick,omitempty"`
+	Token    walkSecret `json:"token"`
+}
+
+func TestGoValueMatchesMarshal(t *testing.T) {
+	u := &walkUser{Name: "a", Password: "secret", Token: walkSecret{"t"}}
+	for _, p := range []string{"Password", "password", "nick", "Nick"} {
+		if x, ps := Lookup(u, p); ps != Missing {
+			t.Fatalf("%s visible: %v", p, x)
+		}
+	}
+	if Any(u, "name") != "a" || Any(u, "Name") != nil {
+		t.Fatal("name should only be reachable by its json name")
+	}
+	// 自定义 MarshalJSON 的类型按序列化结果查询
+	if v := Any(u, "token.masked"); v != true {
+		t.Fatalf("token.masked = %v", v)
+	}
+	if Any(u, "token.v") != nil {
+		t.Fatal("unexported field visible through Marshaler")
+	}
+}
+
+func TestLineReader(t *testing.T) {
+	long := `{"sku":"` + strings.Repeat("x", 100) + `"}`
+	in := "{\"sku\":\"a\"}\r\n\n{bad json}\n" + long + "\n  {\"sku\":\"b\"}"
+	lr := NewLineReader(strings.NewReader(in), LineReaderOptions{BufferSize: 16})
+	type item struct {
+		SKU string `json:"sku"`
+	}
+	var skus []string
+	var bad []int
+	for lr.Next() {
+		it, err := ReadAs[item](lr)
+		if err != nil {
+			var le *LineError
+			if !errors.As(err, &le) {
+				t.Fatalf("want *LineError, got %v", err)
+			}
+			bad = append(bad, le.Line)
+			continue
+		}
+		skus = append(skus, it.SKU)
+	}
+	if lr.Err() != nil {
+		t.Fatal(lr.Err())
+	}
+	if len(skus) != 3 || skus[0] != "a" || len(skus[1]) != 100 || skus[2] != "b" {
+		t.Fatalf("skus = %v", skus)
+	}
+	if len(bad) != 1 || bad[0] != 3 {
+		t.Fatalf("bad lines = %v", bad)
+	}
+
+	lr = NewLineReader(strings.NewReader(long+"\n{}\n"), LineReaderOptions{BufferSize: 16, MaxLineSize: 32})
+	if !lr.Next() || !errors.Is(lr.LineErr(), errLineTooLong) {
+		t.Fatalf("want too long, got %v", lr.LineErr())
+	}
+	if !lr.Next() || lr.LineErr() != nil || lr.Line() != 2 {
+		t.Fatalf("line 2: %v", lr.LineErr())
+	}
+
+	// 行内容不必是对象
+	lr = NewLineReader(strings.NewReader("1\n2\n300\n"), LineReaderOptions{})
+	var sum int
+	for lr.Next() {
+		n, err := ReadAs[int8](lr)
+		if err != nil {
+			if lr.Line() != 3 || !errors.Is(err, ErrOutOfRange) {
+				t.Fatalf("line %d: %v", lr.Line(), err)
+			}
+			continue
+		}
+		sum += int(n)
+	}
+	if sum != 3 {
+		t.Fatalf("sum = %d", sum)
+	}
+}
+
+func TestStreamArray(t *testing.T) {
+	in := `{"meta":{"total":3},"data":{"items":[{"sku":"a"},{"sku":"b"},{"sku":"c"}],"after":1}}`
+	var skus []string
+	err := StreamArray(strings.NewReader(in), "data.items", func(i int, n zeronode.Node) bool {
+		skus = append(skus, n.Get("sku").String())
+		return i < 1
+	})
+	if err != nil || strings.Join(skus, ",") != "a,b" {
+		t.Fatalf("StreamArray = %v, %v", skus, err)
+	}
+
+	type item struct {
+		SKU string `json:"sku"`
+	}
+	var items []item
+	err = StreamArrayAs(strings.NewReader(in), "data.items", func(i int, v item) bool {
+		items = append(items, v)
+		return true
+	})
+	if err != nil || len(items) != 3 || items[2].SKU != "c" {
+		t.Fatalf("StreamArrayAs = %v, %v", items, err)
+	}
+	var nums []int
+	err = StreamArrayAs(strings.NewReader(`{"n":[1,2,3]}`), "n", func(i int, v int) bool {
+		nums = append(nums, v)
+		return true
+	})
+	if err != nil || len(nums) != 3 || nums[2] != 3 {
+		t.Fatalf("StreamArrayAs[int] = %v, %v", nums, err)
+	}
+	if err := StreamArray(strings.NewReader(in), "meta", func(int, zeronode.Node) bool { return true }); err == nil {
+		t.Fatal("want not-array error")
+	}
+	err = StreamArray(strings.NewReader(in), "data.missing", func(int, zeronode.Node) bool { return true })
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "data.missing") {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+}
+
+func TestPartialInput(t *testing.T) {
+	p, err := zeronode.ParsePartial([]byte(`{"user":{"id":7,"tags":["a","b`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Any(p, "user.id"); got != int64(7) {
+		t.Fatalf("Any(partial) = %#v", got)
+	}
+	if got, ok := AnyAs[string](p, "user.tags.0"); !ok || got != "a" || !p.IsIncomplete("user.tags.1") {
+		t.Fatalf("AnyAs(partial) = %v, %v", got, ok)
+	}
+}
+
+func TestRepair(t *testing.T) {
+	out, fixes, err := Repair([]byte(`{name: 'Bob', ok: True,}`))
+	if err != nil || len(fixes) != 5 {
+		t.Fatalf("Repair = %s, %v, %v", out, fixes, err)
+	}
+	if Any(out, "name") != "Bob" || Any(out, "ok") != true {
+		t.Fatalf("repaired = %s", out)
+	}
+}
+
+func TestParseJSONC(t *testing.T) {
+	n, err := Parse([]byte(`{
+  // listen port
+  "server": {"port": 8080,},
+}`), JSONC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port, ok := AnyAs[int64](n, "server.port"); !ok || port != 8080 {
+		t.Fatalf("port = %v, %v", port, ok)
+	}
+}
+
+func TestGetStructWithoutMatchingFields(t *testing.T) {
+	type P struct {
+		Name string `json:"name"`
+	}
+	data := []byte(`{"p":{},"q":{"other":1},"list":[{},{"name":"x"}]}`)
+	for _, path := range []string{"p", "q"} {
+		if v, err := Get[P](data, path); err != nil || v.Name != "" {
+			t.Fatalf("%s: %+v, %v", path, v, err)
+		}
+	}
+	if v, err := Get[[]P](data, "list"); err != nil || len(v) != 2 || v[1].Name != "x" {
+		t.Fatalf("list: %+v, %v", v, err)
+	}
+}
+
+func TestGetTypedErrors(t *testing.T) {
+	data := []byte(`{"user":{"name":"Ann","age":30,"nick":null}}`)
+	if name, err := Get[string](data, "user.name"); err != nil || name != "Ann" {
+		t.Fatalf("Get = %v, %v", name, err)
+	}
+	if _, err := Get[string](data, "user.missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+	_, err := Get[string](data, "user.age")
+	var tm *ErrTypeMismatch
+	if !errors.As(err, &tm) || tm.Path != "user.age" || tm.Want != "string" || tm.Got != "number" {
+		t.Fatalf("want mismatch, got %v", err)
+	}
+	if !errors.Is(err, &ErrTypeMismatch{Got: "number"}) {
+		t.Fatal("errors.Is on mismatch")
+	}
+	if _, err := Get[string](data, "user.nick"); !errors.As(err, &tm) || tm.Got != "null" {
+		t.Fatalf("null: %v", err)
+	}
+	if v, err := Get[any](data, "user.nick"); err != nil || v != nil {
+		t.Fatalf("Get[any] null = %v, %v", v, err)
+	}
+	if _, err := Get[string]([]byte{0xff, '{'}, "a"); !errors.Is(err, ErrInvalidJSON) {
+		t.Fatalf("want ErrInvalidJSON, got %v", err)
+	}
+	if _, err := Get[string](`{"a":`, "b"); !errors.Is(err, ErrInvalidJSON) {
+		t.Fatalf("want ErrInvalidJSON for syntax, got %v", err)
+	}
+	if _, err := Get[string](map[string]any{"a": 1}, "b"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Go value: want ErrNotFound, got %v", err)
+	}
+}
+
+func TestLookupPresence(t *testing.T) {
+	data := []byte(`{"a":null,"b":0,"c":{"d":null}}`)
+	for path, want := range map[string]Presence{"a": Null, "b": Present, "c.d": Null, "x": Missing, "c.x": Missing} {
+		if _, got := Lookup(data, path); got != want {
+			t.Fatalf("Lookup(%s) = %v, want %v", path, got, want)
+		}
+	}
+	if !Exists(data, "a") || IsNull(data, "b") || Exists(data, "x") || IsNull(data, "x") {
+		t.Fatal("Exists / IsNull mismatch")
+	}
+	m := map[string]any{"a": nil, "b": []any(nil), "c": 1}
+	for path, want := range map[string]Presence{"a": Null, "b": Null, "c": Present, "d": Missing} {
+		if _, got := Lookup(m, path); got != want {
+			t.Fatalf("Lookup(map, %s) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestAnyAsLoose(t *testing.T) {
+	data := []byte(`{"age":"30","on":1,"yes":" Yes ","id":12345678901234567,"ratio":"0.5","big":"300","frac":1.5,"neg":-1}`)
+	if v, ok := AnyAsLoose[int64](data, "age"); !ok || v != 30 {
+		t.Fatalf("age = %v, %v", v, ok)
+	}
+	if v, ok := AnyAsLoose[int](data, "age"); !ok || v != 30 {
+		t.Fatalf("age int = %v, %v", v, ok)
+	}
+	if v, ok := AnyAsLoose[bool](data, "on"); !ok || !v {
+		t.Fatal("on")
+	}
+	if v, ok := AnyAsLoose[bool](data, "yes"); !ok || !v {
+		t.Fatal("yes")
+	}
+	if v, ok := AnyAsLoose[string](data, "id"); !ok || v != "12345678901234567" {
+		t.Fatalf("id = %v", v)
+	}
+	if v, ok := AnyAsLoose[float32](data, "ratio"); !ok || v != 0.5 {
+		t.Fatalf("ratio = %v", v)
+	}
+	if _, err := GetLoose[int8](data, "big"); !errors.Is(err, errs.ErrOutOfRange) {
+		t.Fatalf("want overflow, got %v", err)
+	}
+	if _, err := GetLoose[uint](data, "neg"); !errors.Is(err, errs.ErrOutOfRange) {
+		t.Fatalf("want overflow for negative uint, got %v", err)
+	}
+	exact := []byte(`{"n":"9007199254740993.0","e":"1e3","huge":"1e400","half":"0.5","t":"T"}`)
+	if v, err := GetLoose[int64](exact, "n"); err != nil || v != 9007199254740993 {
+		t.Fatalf("exact = %v, %v", v, err)
+	}
+	if v, err := GetLoose[uint16](exact, "e"); err != nil || v != 1000 {
+		t.Fatalf("1e3 = %v, %v", v, err)
+	}
+	if _, err := GetLoose[int64](exact, "huge"); !errors.Is(err, errs.ErrOutOfRange) {
+		t.Fatalf("want overflow for 1e400, got %v", err)
+	}
+	if _, err := GetLoose[uint64](exact, "huge"); !errors.Is(err, errs.ErrOutOfRange) {
+		t.Fatalf("want overflow for 1e400 uint, got %v", err)
+	}
+	if _, err := GetLoose[int](exact, "half"); !errors.As(err, new(*ErrTypeMismatch)) {
+		t.Fatalf("want mismatch for 0.5, got %v", err)
+	}
+	if v, ok := AnyAsLoose[bool](exact, "t"); !ok || !v {
+		t.Fatal("t")
+	}
+	var tm *ErrTypeMismatch
+	if _, err := GetLoose[int](data, "frac"); !errors.As(err, &tm) || tm.Path != "frac" {
+		t.Fatalf("want mismatch, got %v", err)
+	}
+	if v, ok := AnyAsLoose[int](map[string]any{"n": "7"}, "n"); !ok || v != 7 {
+		t.Fatalf("Go value = %v, %v", v, ok)
+	}
+}
+
+func TestAnyAsAnyType(t *testing.T) {
+	data := []byte(`{"n":42,"big":300,"f":1.5,"ts":"2024-01-02T03:04:05Z","tags":["a","b"],
+		"counts":{"x":1,"y":2},"pair":[1,2],"user":{"name":"Ann"}}`)
+	if v, ok := AnyAs[int32](data, "n"); !ok || v != 42 {
+		t.Fatalf("int32 = %v, %v", v, ok)
+	}
+	if v, ok := AnyAs[uint64](data, "n"); !ok || v != 42 {
+		t.Fatalf("uint64 = %v, %v", v, ok)
+	}
+	if _, ok := AnyAs[int8](data, "big"); ok {
+		t.Fatal("int8 overflow should fail")
+	}
+	if v, ok := AnyAs[float32](data, "f"); !ok || v != 1.5 {
+		t.Fatalf("float32 = %v, %v", v, ok)
+	}
+	if v, ok := AnyAs[time.Time](data, "ts"); !ok || v.Year() != 2024 {
+		t.Fatalf("time = %v, %v", v, ok)
+	}
+	if v, ok := AnyAs[[]string](data, "tags"); !ok || len(v) != 2 || v[1] != "b" {
+		t.Fatalf("[]string = %v, %v", v, ok)
+	}
+	if v, ok := AnyAs[map[string]int](data, "counts"); !ok || v["y"] != 2 {
+		t.Fatalf("map = %v, %v", v, ok)
+	}
+	if v, ok := AnyAs[[2]int](data, "pair"); !ok || v != [2]int{1, 2} {
+		t.Fatalf("array = %v, %v", v, ok)
+	}
+	type user struct {
+		Name string `json:"name"`
+	}
+	if v, ok := AnyAs[*user](data, "user"); !ok || v.Name != "Ann" {
+		t.Fatalf("*struct = %v, %v", v, ok)
+	}
+	if v, ok := AnyAsFast[int](data, "n"); !ok || v != 42 {
+		t.Fatalf("AnyAsFast[int] = %v, %v", v, ok)
+	}
+	if v, ok := AnyAsFast[[]string](data, "tags"); !ok || len(v) != 2 {
+		t.Fatalf("AnyAsFast[[]string] = %v, %v", v, ok)
+	}
+
+	// Go 值输入：类型不一致时按 JSON 语义转换
+	m := map[string]any{"n": int64(7), "tags": []any{"x"}}
+	if v, ok := AnyAs[int](m, "n"); !ok || v != 7 {
+		t.Fatalf("go int = %v, %v", v, ok)
+	}
+	if v, ok := AnyAs[[]string](m, "tags"); !ok || v[0] != "x" {
+		t.Fatalf("go []string = %v, %v", v, ok)
+	}
+
+	_, err := Get[int8](data, "big")
+	var re *structfast.RangeError
+	if !errors.Is(err, ErrOutOfRange) || !errors.As(err, &re) || re.Path != "big" {
+		t.Fatalf("Get[int8] = %v", err)
+	}
+	_, err = Get[[]int](data, "tags")
+	var tm *ErrTypeMismatch
+	if !errors.As(err, &tm) || tm.Path != "tags.0" {
+		t.Fatalf("Get[[]int] = %v", err)
+	}
+}
+
+func TestIntegerBounds(t *testing.T) {
+	data := []byte(`{"min":-9223372036854775808,"max":9223372036854775807,"umax":18446744073709551615,"under":-9223372036854775809}`)
+	if v, ok := AnyAs[int64](data, "min"); !ok || v != math.MinInt64 {
+		t.Fatalf("min = %v, %v", v, ok)
+	}
+	if v, ok := AnyAs[int64](data, "max"); !ok || v != math.MaxInt64 {
+		t.Fatalf("max = %v, %v", v, ok)
+	}
+	if v, ok := AnyAs[uint64](data, "umax"); !ok || v != math.MaxUint64 {
+		t.Fatalf("umax = %v, %v", v, ok)
+	}
+	if _, ok := AnyAs[int64](data, "under"); ok {
+		t.Fatal("under should overflow int64")
+	}
+	if v := Any(data, "min"); v != int64(math.MinInt64) {
+		t.Fatalf("Any(min) = %#v", v)
+	}
+	if v := Any(data, "umax"); v != uint64(math.MaxUint64) {
+		t.Fatalf("Any(umax) = %#v", v)
+	}
+	if v := parser.ToNativeBytes(data).(map[string]any)["min"]; v != int64(math.MinInt64) {
+		t.Fatalf("ToNativeBytes(min) = %#v", v)
+	}
+}
+
+func TestPreciseNumbers(t *testing.T) {
+	data := []byte(`{"order":{"id":12345678901234567,"amount":0.1,"items":[{"sku":99999999999999999999}]}}`)
+	if v, ok := AnyAs[*big.Int](data, "order.items.0.sku"); !ok || v.String() != "99999999999999999999" {
+		t.Fatalf("big.Int = %v, %v", v, ok)
+	}
+	if v, ok := AnyAs[json.Number](data, "order.amount"); !ok || v != "0.1" {
+		t.Fatalf("json.Number = %v, %v", v, ok)
+	}
+	o := NativeOptions{UseNumber: true}
+	if v := AnyWith(data, "order.id", o); v != json.Number("12345678901234567") {
+		t.Fatalf("AnyWith = %#v", v)
+	}
+	m := MapAnyWith(data, "order", o)
+	if m["amount"] != json.Number("0.1") || m["items"].([]any)[0].(map[string]any)["sku"] != json.Number("99999999999999999999") {
+		t.Fatalf("MapAnyWith = %#v", m)
+	}
+	if _, ok := Any(data, "order.id").(int64); !ok {
+		t.Fatal("Any default should stay int64")
+	}
+}
+
+func TestOrderedMap(t *testing.T) {
+	data := []byte(`{"resp":{"z":1,"a":{"y":"<b>","b":[{"k2":1,"k1":2}]},"z":4}}`)
+	m := OrderedMapAny(data, "resp", NativeOptions{})
+	if m == nil || strings.Join(m.Keys(), ",") != "z,a,z" {
+		t.Fatalf("keys = %v", m.Keys())
+	}
+	if v, _ := m.Get("z"); v != int64(1) {
+		t.Fatalf("Get dup = %v", v)
+	}
+	out, err := m.MarshalJSON()
+	if err != nil || string(out) != `{"z":1,"a":{"y":"<b>","b":[{"k2":1,"k1":2}]},"z":4}` {
+		t.Fatalf("Marshal = %s, %v", out, err)
+	}
+	var back OrderedMap
+	if err := json.Unmarshal(out, &back); err != nil || back.Len() != 3 {
+		t.Fatalf("Unmarshal = %v, %v", back.Keys(), err)
+	}
+	back.Delete("z")
+	back.Set("n", 1)
+	if strings.Join(back.Keys(), ",") != "a,n" {
+		t.Fatalf("after edit = %v", back.Keys())
+	}
+
+	if _, ok := AnyWith(data, "resp.a", NativeOptions{Ordered: true}).(*OrderedMap); !ok {
+		t.Fatal("AnyWith Ordered")
+	}
+	flat := MapAnyWith(data, "resp", NativeOptions{Ordered: true})
+	if _, ok := flat["a"].(*OrderedMap); !ok || flat["z"] != int64(1) {
+		t.Fatalf("MapAnyWith = %#v", flat)
+	}
+	type pair struct {
+		B int `json:"b"`
+		A int `json:"a"`
+	}
+	if om := OrderedMapAny(map[string]any{"p": pair{1, 2}}, "p", NativeOptions{}); strings.Join(om.Keys(), ",") != "b,a" {
+		t.Fatalf("struct keys = %v", om.Keys())
+	}
+}
+
+func TestDupPolicy(t *testing.T) {
+	defer SetDupPolicy(GetDupPolicy())
+	data := []byte(`{"role":"user","x":{"a":1,"b":2,"a":3},"role":"admin"}`)
+
+	if v := Any(data, "role"); v != "user" {
+		t.Fatalf("first: Any = %v", v)
+	}
+	if v, _ := Raw(data, "x.a"); v != "1" {
+		t.Fatalf("first: Raw = %v", v)
+	}
+	if m := MapAny(data, "x"); m["a"] != int64(1) {
+		t.Fatalf("first: MapAny = %v", m)
+	}
+	// 物化入口默认也是第一个生效（过去为最后一个），DupLast 可恢复旧结果
+	if m := parser.ToNativeBytes(data).(map[string]any); m["role"] != "user" {
+		t.Fatalf("first: ToNativeBytes = %v", m)
+	}
+
+	SetDupPolicy(DupLast)
+	if v := Any(data, "role"); v != "admin" {
+		t.Fatalf("last: Any = %v", v)
+	}
+	if v, _ := Raw(data, "x.a"); v != "3" {
+		t.Fatalf("last: Raw = %v", v)
+	}
+	if m := MapAny(data, "x"); m["a"] != int64(3) {
+		t.Fatalf("last: MapAny = %v", m)
+	}
+	if m := parser.ToNativeBytes(data).(map[string]any); m["role"] != "admin" {
+		t.Fatalf("last: ToNativeBytes = %v", m)
+	}
+	var s struct {
+		Role string           `json:"role"`
+		X    map[string]int64 `json:"x"`
+	}
+	if err := structfast.DecodeErr(zeronode.FromBytes(data), &s); err != nil || s.Role != "admin" || s.X["a"] != 3 {
+		t.Fatalf("last: decode = %+v, %v", s, err)
+	}
+
+	SetDupPolicy(DupError)
+	if _, err := Get[string](data, "role"); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("error: Get = %v", err)
+	}
+	if _, err := Get[int](data, "x.b"); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("error: unrelated path = %v", err)
+	}
+	if _, err := raw.GetErr(data, "x.b"); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("error: raw = %v", err)
+	}
+	if err := structfast.DecodeErr(zeronode.FromBytes(data), &s); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("error: decode = %v", err)
+	}
+	if _, ok := zeronode.FromBytes(data).Get("x").ObjectKey("a"); ok {
+		t.Fatal("error: Node lookup should miss")
+	}
+	// 物化与 Node.Get 一致：不静默取其中一个值
+	if v := parser.ToNativeBytes(data); v != nil {
+		t.Fatalf("error: ToNativeBytes = %v", v)
+	}
+	if _, err := parser.ToNativeBytesErr(data, parser.NativeOptions{}); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("error: ToNativeBytesErr = %v", err)
+	}
+	om := parser.NewOrderedMap(3)
+	om.Append("a", 1)
+	om.Append("a", 2)
+	om.Append("b", 3)
+	if _, ok := om.Get("a"); ok || om.Map() != nil {
+		t.Fatal("error: OrderedMap should not pick a duplicate")
+	}
+	if v, ok := om.Get("b"); !ok || v != 3 {
+		t.Fatalf("error: OrderedMap.Get(b) = %v", v)
+	}
+	om.Set("a", 4)
+	if v, ok := om.Get("a"); !ok || v != 4 || om.Len() != 2 {
+		t.Fatalf("error: OrderedMap.Set = %v, %v", v, om.Keys())
+	}
+
+	dups, err := FindDuplicates(`{"a":[{"k":1,"k":2}],"a":0}`)
+	if err != nil || len(dups) != 2 || dups[0].Path != "a.0.k" || dups[1].Path != "a" || len(dups[1].Offsets) != 2 {
+		t.Fatalf("FindDuplicates = %+v, %v", dups, err)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	defer SetLimits(GetLimits())
+	data := []byte(`{"list":[1,2,3,4],"deep":[[[[1]]]]}`)
+	if err := CheckLimits(data, Limits{MaxArrayLen: 3}); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("CheckLimits = %v", err)
+	}
+
+	l := GetLimits()
+	l.MaxArrayLen, l.MaxDepth = 3, 3
+	SetLimits(l)
+	if _, err := Get[[]int](data, "list"); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("Get slice = %v", err)
+	}
+	if _, err := Get[any](data, "deep"); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("Get any = %v", err)
+	}
+	if v := Any(data, "deep.0.0"); v == nil {
+		t.Fatal("shallow subtree should materialize")
+	}
+	if _, err := parser.ToNativeBytesErr(data, parser.NativeOptions{Limits: &Limits{}}); err != nil {
+		t.Fatalf("per-call override = %v", err)
+	}
+
+	SetLimits(Limits{MaxBytes: 8})
+	if _, err := Get[int](data, "list.0"); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("MaxBytes = %v", err)
+	}
+	if _, err := raw.GetErr(data, "list.0"); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("raw MaxBytes = %v", err)
+	}
+	// 全局 MaxBytes 不约束物化与解码
+	if v := parser.ToNativeBytes(data); v == nil {
+		t.Fatal("ToNativeBytes limited by MaxBytes")
+	}
+	var st struct {
+		List []int `json:"list"`
+	}
+	if !structfast.Decode(zeronode.FromBytes(data), &st) {
+		t.Fatal("Decode limited by MaxBytes")
+	}
+
+	// 已弃用的 raw.MaxJSONSize 保持原默认值，改为其他值后总是生效
+	if raw.MaxJSONSize != 10<<20 {
+		t.Fatalf("MaxJSONSize default = %d", raw.MaxJSONSize)
+	}
+	defer func(n int) { raw.MaxJSONSize = n }(raw.MaxJSONSize)
+	raw.MaxJSONSize = len(data)
+	if _, err := Get[int](data, "list.0"); err != nil {
+		t.Fatalf("raised MaxJSONSize = %v", err)
+	}
+	raw.MaxJSONSize = 8
+	SetLimits(Limits{})
+	if _, err := raw.GetErr(data, "list.0"); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("lowered MaxJSONSize = %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package gcjson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/icloudza/gcjson/convert"
+	"github.com/icloudza/gcjson/errs"
+	"github.com/icloudza/gcjson/parser"
+	"github.com/icloudza/gcjson/structfast"
+	"github.com/icloudza/gcjson/zeronode"
+	"github.com/tidwall/gjson"
+)
+
+// 错误定义见 errs 包，这里导出同一实例，便于 errors.Is / errors.As
+var (
+	ErrNotFound      = errs.ErrNotFound
+	ErrInvalidJSON   = errs.ErrInvalidJSON
+	ErrTooLarge      = errs.ErrTooLarge
+	ErrOutOfRange    = errs.ErrOutOfRange
+	ErrDuplicateKey  = errs.ErrDuplicateKey
+	ErrLimitExceeded = errs.ErrLimitExceeded
+)
+
+// ErrTypeMismatch 路径上的值与目标类型不符，见 errs.ErrTypeMismatch
+type ErrTypeMismatch = errs.ErrTypeMismatch
+
+// Get 同 AnyAs，但用错误区分失败原因：
+//
+//   - 路径不存在：ErrNotFound
+//
+//   - 类型不符：*ErrTypeMismatch（JSON null 写入标量或结构体也按类型不符处理，
+//     T 为指针、切片、map 或接口类型时返回零值）；数值溢出：ErrOutOfRange
+//
+//   - 输入非法 / 过大：ErrInvalidJSON / ErrTooLarge
+//
+//   - 重复键策略为 DupError 且输入含重复键：ErrDuplicateKey
+//
+//   - 结果超出全局 Limits（嵌套层数、元素数等）：ErrLimitExceeded
+//
+//     name, err := gcjson.Get[string](data, "user.name")
+//     if errors.Is(err, gcjson.ErrNotFound) { ... }
+func Get[T any](v any, path string) (T, error) {
+	var zero T
+	x, r, isGo, err := resolve(v, path)
+	if err != nil {
+		return zero, err
+	}
+	var out T
+	if isGo {
+		out, err = goValueAs[T](x)
+	} else {
+		out, err = resultAs[T](r)
+	}
+	if err != nil {
+		return zero, withPath(err, path)
+	}
+	return out, nil
+}
+
+// GetLoose 同 Get，但标量在字符串、数字、布尔之间按宽松规则转换
+// （如 "30" → int64、1 → true、123 → "123"），规则见 parser.CoerceValue。
+// 溢出返回 ErrOutOfRange，无法转换返回 *ErrTypeMismatch。
+func GetLoose[T any](v any, path string) (T, error) {
+	var zero T
+	x, r, isGo, err := resolve(v, path)
+	if err != nil {
+		return zero, err
+	}
+	if !isGo {
+		if r.Type == gjson.Number {
+			x = json.Number(r.Raw) // 保留精度，避免大整数经 float64 转换
+		} else {
+			x = parser.ToNative(r)
+		}
+	}
+	if out, ok := x.(T); ok {
+		return out, nil
+	}
+	out, err := parser.Coerce[T](x)
+	if err != nil {
+		var tm *ErrTypeMismatch
+		if errors.As(err, &tm) {
+			tm.Path = path
+			return zero, tm
+		}
+		return zero, fmt.Errorf("%w at %q", err, path)
+	}
+	return out, nil
+}
+
+// AnyAsLoose 同 GetLoose，失败时返回 false
+func AnyAsLoose[T any](v any, path string) (T, bool) {
+	out, err := GetLoose[T](v, path)
+	return out, err == nil
+}
+
+// resolve 定位 path：Go 值输入返回原值 x（isGo），JSON 输入返回 gjson.Result
+func resolve(v any, path string) (x any, r gjson.Result, isGo bool, err error) {
+	if x, found, handled := walkValue(v, path); handled {
+		if !found {
+			return nil, r, true, errs.NotFound(path)
+		}
+		return x, r, true, nil
+	}
+	b, err := convert.From(v)
+	if err != nil {
+		return nil, r, false, err
+	}
+	if b, err = checkInput(b); err != nil {
+		return nil, r, false, err
+	}
+	if r = get(b, path); !r.Exists() {
+		// 只在未命中时校验，避免每次查询都完整扫描
+		if !gjson.ValidBytes(b) {
+			return nil, r, false, errs.InvalidJSON("syntax error")
+		}
+		return nil, r, false, errs.NotFound(path)
+	}
+	return nil, r, false, nil
+}
+
+// resultAs 将查询结果转换为 T：parser.ToNative 能直接产出的类型走类型断言，
+// 其余（各宽度整数、float32、time.Time、切片、map、结构体等）经 structfast 解码，
+// 数值做范围检查，不截断。
+func resultAs[T any](r gjson.Result) (T, error) {
+	if isNativeTarget[T]() {
+		x, err := parser.ToNativeErr(r, parser.NativeOptions{})
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		if out, ok := assertAs[T](x); ok {
+			return out, nil
+		}
+	}
+	var out T
+	err := structfast.DecodeValue(zeronode.FromBytes(convert.UnsafeStringToBytes(r.Raw)), &out)
+	return out, err
+}
+
+// goValueAs Go 值输入：类型一致直接返回，否则序列化该子树后按 JSON 语义解码
+func goValueAs[T any](x any) (T, error) {
+	if out, ok := x.(T); ok {
+		return out, nil
+	}
+	var out T
+	b, err := convert.Marshal(x)
+	if err != nil {
+		return out, err
+	}
+	err = structfast.DecodeValue(zeronode.FromBytes(b), &out)
+	return out, err
+}
+
+// isNativeTarget T 是否为 parser.ToNative 可能直接产出的类型
+func isNativeTarget[T any]() bool {
+	switch any((*T)(nil)).(type) {
+	case *string, *bool, *int64, *float64, *map[string]any, *[]any:
+		return true
+	}
+	return reflect.TypeFor[T]().Kind() == reflect.Interface
+}
+
+// withPath 为解码错误补上查询路径前缀
+func withPath(err error, path string) error {
+	var tm *ErrTypeMismatch
+	if errors.As(err, &tm) {
+		tm.Path = joinPath(path, tm.Path)
+		return err
+	}
+	var re *structfast.RangeError
+	if errors.As(err, &re) {
+		re.Path = joinPath(path, re.Path)
+	}
+	return err
+}
+
+func joinPath(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "." + b
+}
+
+// assertAs 类型断言；nil 可以赋给接口类型的 T
+func assertAs[T any](x any) (T, bool) {
+	if x == nil {
+		var zero T
+		return zero, reflect.TypeFor[T]().Kind() == reflect.Interface
+	}
+	return parser.ToNativeTyped[T](x)
+}
@@ -0,0 +1,36 @@
+package gcjson
+
+import (
+	"github.com/icloudza/gcjson/convert"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// Limits 输入规模上限，见 zeronode.Limits
+type Limits = zeronode.Limits
+
+// LimitError 超出 Limits 某一项时的错误，见 zeronode.LimitError
+type LimitError = zeronode.LimitError
+
+// SetLimits 设置全局上限：查询入口检查 MaxBytes（raw.MaxJSONSize 被改为非默认值时以它为准），
+// 路径查询不检查层数与元素数；物化（Any 返回 map / 切片、Get、structfast 解码）检查 MaxBytes 以外的各项。处理不可信输入时建议收紧默认值。
+//
+//	l := gcjson.GetLimits()
+//	l.MaxDepth, l.MaxArrayLen = 64, 10000
+//	gcjson.SetLimits(l)
+//	_, err := gcjson.Get[any](body, "items") // errors.Is(err, gcjson.ErrLimitExceeded)
+func SetLimits(l Limits) { zeronode.SetLimits(l) }
+
+// GetLimits 返回当前全局上限
+func GetLimits() Limits { return zeronode.GetLimits() }
+
+// CheckLimits 按 l 单遍扫描 JSON 输入（输入类型同 Any），适合在入口处对单个请求显式检查
+func CheckLimits(v any, l Limits) error {
+	if isStructCandidate(v) {
+		return nil
+	}
+	b, err := convert.From(v)
+	if err != nil {
+		return err
+	}
+	return l.Check(b)
+}
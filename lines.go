@@ -0,0 +1,169 @@
+package gcjson
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/icloudza/gcjson/structfast"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// LineReaderOptions NDJSON / JSON Lines 读取选项，零值即默认值。
+type LineReaderOptions struct {
+	// BufferSize 读缓冲大小，默认 64KB；更长的行会拼接到复用的行缓冲中
+	BufferSize int
+	// MaxLineSize 单行最大字节数，0 表示不限制；超长行报告 LineError 并跳过
+	MaxLineSize int
+}
+
+// LineError 某一行的错误（语法错误、超长或解码失败），不会中断读取。
+type LineError struct {
+	Line int // 行号，从 1 开始（空行也计数）
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return "gcjson: line " + strconv.Itoa(e.Line) + ": " + e.Err.Error()
+}
+
+func (e *LineError) Unwrap() error { return e.Err }
+
+var errLineTooLong = errors.New("line too long")
+
+// LineReader 逐行读取 NDJSON，每行一个 zeronode.Node，跳过空行。
+// 行缓冲在调用间复用：Node / Bytes 仅在下一次 Next 之前有效。
+//
+//	lr := gcjson.NewLineReader(f, gcjson.LineReaderOptions{})
+//	for lr.Next() {
+//	    if err := lr.LineErr(); err != nil {
+//	        log.Println(err) // 坏行不影响后续
+//	        continue
+//	    }
+//	    n := lr.Node()
+//	}
+//	if err := lr.Err(); err != nil { ... } // I/O 错误
+type LineReader struct {
+	r       *bufio.Reader
+	max     int
+	buf     []byte
+	line    int
+	raw     []byte
+	node    zeronode.Node
+	lineErr error
+	err     error
+	eof     bool
+}
+
+// NewLineReader 创建行读取器
+func NewLineReader(r io.Reader, opts LineReaderOptions) *LineReader {
+	size := opts.BufferSize
+	if size <= 0 {
+		size = 64 << 10
+	}
+	return &LineReader{r: bufio.NewReaderSize(r, size), max: opts.MaxLineSize}
+}
+
+// Next 前进到下一个非空行；读到末尾或发生 I/O 错误时返回 false。
+func (lr *LineReader) Next() bool {
+	for !lr.eof && lr.err == nil {
+		line, tooLong, err := lr.readLine()
+		if err == io.EOF {
+			lr.eof = true
+		} else if err != nil {
+			lr.err = err
+			return false
+		}
+		if len(line) == 0 && !tooLong && lr.eof {
+			return false
+		}
+		lr.line++
+		lr.raw, lr.node, lr.lineErr = nil, zeronode.Node{}, nil
+		if tooLong {
+			lr.lineErr = &LineError{Line: lr.line, Err: errLineTooLong}
+			return true
+		}
+		line = trimLineSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		lr.raw = line
+		if e := zeronode.Validate(line); e != nil {
+			lr.lineErr = &LineError{Line: lr.line, Err: e}
+			return true
+		}
+		lr.node = zeronode.FromBytes(line)
+		return true
+	}
+	return false
+}
+
+// readLine 读取一行（含换行符）；能放进读缓冲时零拷贝返回缓冲切片
+func (lr *LineReader) readLine() ([]byte, bool, error) {
+	lr.buf = lr.buf[:0]
+	tooLong := false
+	for {
+		chunk, err := lr.r.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			if !tooLong {
+				lr.buf = append(lr.buf, chunk...)
+				if lr.max > 0 && len(lr.buf) > lr.max {
+					tooLong, lr.buf = true, lr.buf[:0]
+				}
+			}
+			continue
+		}
+		if tooLong {
+			return nil, true, err
+		}
+		if len(lr.buf) == 0 {
+			if lr.max > 0 && len(chunk) > lr.max {
+				return nil, true, err
+			}
+			return chunk, false, err
+		}
+		lr.buf = append(lr.buf, chunk...)
+		if lr.max > 0 && len(lr.buf) > lr.max {
+			return nil, true, err
+		}
+		return lr.buf, false, err
+	}
+}
+
+func trimLineSpace(b []byte) []byte {
+	for len(b) > 0 && (b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n') {
+		b = b[1:]
+	}
+	for len(b) > 0 && (b[len(b)-1] == ' ' || b[len(b)-1] == '\t' || b[len(b)-1] == '\r' || b[len(b)-1] == '\n') {
+		b = b[:len(b)-1]
+	}
+	return b
+}
+
+// Node 当前行的根节点；当前行有错误时为零值
+func (lr *LineReader) Node() zeronode.Node { return lr.node }
+
+// Bytes 当前行去除首尾空白后的原始字节
+func (lr *LineReader) Bytes() []byte { return lr.raw }
+
+// Line 当前行号（从 1 开始）
+func (lr *LineReader) Line() int { return lr.line }
+
+// LineErr 当前行的错误（*LineError），无错误返回 nil
+func (lr *LineReader) LineErr() error { return lr.lineErr }
+
+// Err 返回导致读取终止的 I/O 错误；正常读到末尾返回 nil
+func (lr *LineReader) Err() error { return lr.err }
+
+// ReadAs 用 structfast 将当前行解码为 T；行错误与解码错误都以 *LineError 返回。
+func ReadAs[T any](lr *LineReader) (T, error) {
+	var out T
+	if lr.lineErr != nil {
+		return out, lr.lineErr
+	}
+	if err := structfast.DecodeValue(lr.node, &out); err != nil {
+		return out, &LineError{Line: lr.line, Err: err}
+	}
+	return out, nil
+}
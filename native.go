@@ -0,0 +1,81 @@
+package gcjson
+
+import "github.com/icloudza/gcjson/parser"
+
+// NativeOptions 控制 JSON 到 Go 原生值的转换方式，见 parser.NativeOptions。
+//
+//	id := gcjson.AnyWith(data, "order.id", gcjson.NativeOptions{UseNumber: true}) // json.Number
+type NativeOptions = parser.NativeOptions
+
+// OrderedMap 保留键顺序与重复键的 JSON 对象，见 parser.OrderedMap
+type OrderedMap = parser.OrderedMap
+
+// AnyWith 同 Any，JSON 输入按 o 转换；Go 值输入与 Any 相同，返回路径上的原值
+func AnyWith(v any, path string, o NativeOptions) any {
+	if o == (NativeOptions{}) {
+		return Any(v, path)
+	}
+	if x, found, handled := walkValue(v, path); handled {
+		if !found {
+			return nil
+		}
+		return x
+	}
+	r, err := GetAny(v, path)
+	if err != nil || len(r.Raw) == 0 {
+		return nil
+	}
+	return parser.ToNativeWith(r, o)
+}
+
+// MapAnyWith 同 MapAny，按 o 转换；返回值本身总是普通 map，
+// o.Ordered 只影响嵌套对象，需要保留顶层顺序时用 OrderedMapAny
+func MapAnyWith(v any, path string, o NativeOptions) map[string]any {
+	x := AnyWith(v, path, o)
+	switch m := x.(type) {
+	case map[string]any:
+		return m
+	case *OrderedMap:
+		return m.Map()
+	}
+	// Go 值输入命中结构体 / map[string]T：按 JSON 语义转换
+	if x != nil && isStructCandidate(v) {
+		switch m := toNativeValue(x, o).(type) {
+		case map[string]any:
+			return m
+		case *OrderedMap:
+			return m.Map()
+		}
+	}
+	return nil
+}
+
+// OrderedMapAny 取 path 处的对象并保留原始键顺序（含重复键），嵌套对象同样为 *OrderedMap。
+// 总是启用 o.Ordered；结构体输入按字段顺序。不是对象时返回 nil。
+func OrderedMapAny(v any, path string, o NativeOptions) *OrderedMap {
+	o.Ordered = true
+	x := AnyWith(v, path, o)
+	if m, ok := x.(*OrderedMap); ok {
+		return m
+	}
+	if x != nil && isStructCandidate(v) {
+		if m, ok := toNativeValue(x, o).(*OrderedMap); ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// ArrayAnyWith 同 ArrayAny，按 o 转换
+func ArrayAnyWith(v any, path string, o NativeOptions) []any {
+	x := AnyWith(v, path, o)
+	if a, ok := x.([]any); ok {
+		return a
+	}
+	if x != nil && isStructCandidate(v) {
+		if a, ok := toNativeValue(x, o).([]any); ok {
+			return a
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package gcjson
+
+import "github.com/icloudza/gcjson/zeronode"
+
+// Mode 输入语法模式，见 zeronode.Mode
+type Mode = zeronode.Mode
+
+const (
+	Strict = zeronode.Strict // 标准 JSON
+	JSONC  = zeronode.JSONC  // 允许注释与尾逗号
+	JSON5  = zeronode.JSON5  // 另允许裸键、单引号字符串、十六进制数字
+)
+
+// Parse 按 mode 校验 b 并返回根节点，不改写输入。
+// 返回的节点可直接用于 zeronode 的路径查找与 structfast 解码，
+// 也可作为本包各查询函数与 raw 的输入（内部会规范化为合法 JSON）。
+//
+//	cfg, err := gcjson.Parse(data, gcjson.JSONC)
+//	port, _ := gcjson.AnyAs[int64](cfg, "server.port")
+func Parse(b []byte, mode Mode) (zeronode.Node, error) { return zeronode.Parse(b, mode) }
@@ -26,22 +26,27 @@ func ParseIntFastBytes(b []byte) (int64, bool) {
 			return 0, false
 		}
 	}
-	var n int64
+	// 以 uint64 累加，负数上界为 1<<63，才能接受 math.MinInt64
+	limit := uint64(1<<63 - 1)
+	if neg {
+		limit = 1 << 63
+	}
+	var n uint64
 	for ; i < len(b); i++ {
 		c := b[i] - '0'
 		if c > 9 {
 			return 0, false
 		}
-		d := int64(c)
-		if n > (9223372036854775807-d)/10 {
+		d := uint64(c)
+		if n > (limit-d)/10 {
 			return 0, false
 		}
 		n = n*10 + d
 	}
 	if neg {
-		n = -n
+		return int64(-n), true
 	}
-	return n, true
+	return int64(n), true
 }
 
 // ParseFloat64Bytes 直接用标准库的零拷贝接口	：strconv.ParseFloat 需要 string，但只在确认为非整数时调用
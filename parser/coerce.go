@@ -0,0 +1,317 @@
+package parser
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/icloudza/gcjson/errs"
+)
+
+// Coerce 按宽松规则把标量 x 转换为 T，规则见 CoerceValue。
+func Coerce[T any](x any) (T, error) {
+	var out T
+	v, err := CoerceValue(x, reflect.TypeFor[T]())
+	if err != nil {
+		return out, err
+	}
+	reflect.ValueOf(&out).Elem().Set(v)
+	return out, nil
+}
+
+// CoerceValue 按宽松规则把标量 x（Go 的字符串、布尔、各宽度整数/浮点数或 json.Number）
+// 转换为类型 t 的值。根包 AnyAsLoose 与 structfast 的 Options.Loose 共用这套规则：
+//
+//	目标          接受的输入
+//	string       字符串；整数/浮点数转十进制文本（浮点取最短表示）；布尔转 "true"/"false"
+//	bool         布尔；数字 0/1；字符串 true/false/t/f/1/0/yes/no/y/n/on/off（不区分大小写）
+//	int*/uint*   整数；小数部分为 0 的浮点数；整数或整值数字的字符串（如 "3.0"、"1e3"，按十进制精确判断）；布尔为 1/0
+//	float*       数字；数字字符串；布尔为 1/0
+//
+// 字符串会先去除首尾空白。超出目标范围返回 errs.ErrOutOfRange（不截断）；
+// null、对象、数组以及无法解析的字符串返回 *errs.ErrTypeMismatch。
+func CoerceValue(x any, t reflect.Type) (reflect.Value, error) {
+	out := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		s, ok := coerceString(x)
+		if !ok {
+			return out, mismatch(x, t)
+		}
+		out.SetString(s)
+	case reflect.Bool:
+		b, ok := coerceBool(x)
+		if !ok {
+			return out, mismatch(x, t)
+		}
+		out.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := coerceInt(x, t)
+		if err != nil {
+			return out, err
+		}
+		if out.OverflowInt(i) {
+			return out, errs.OutOfRange(strconv.FormatInt(i, 10), t.String())
+		}
+		out.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := coerceUint(x, t)
+		if err != nil {
+			return out, err
+		}
+		if out.OverflowUint(u) {
+			return out, errs.OutOfRange(strconv.FormatUint(u, 10), t.String())
+		}
+		out.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := coerceFloat(x, t)
+		if err != nil {
+			return out, err
+		}
+		if out.OverflowFloat(f) {
+			return out, errs.OutOfRange(strconv.FormatFloat(f, 'g', -1, 64), t.String())
+		}
+		out.SetFloat(f)
+	case reflect.Interface:
+		if x != nil && !reflect.TypeOf(x).AssignableTo(t) {
+			return out, mismatch(x, t)
+		}
+		if x != nil {
+			out.Set(reflect.ValueOf(x))
+		}
+	default:
+		if x != nil && reflect.TypeOf(x).AssignableTo(t) {
+			out.Set(reflect.ValueOf(x))
+			return out, nil
+		}
+		return out, mismatch(x, t)
+	}
+	return out, nil
+}
+
+func mismatch(x any, t reflect.Type) error {
+	return &errs.ErrTypeMismatch{Want: t.String(), Got: errs.JSONType(typeOf(x))}
+}
+
+// typeOf 返回 Go 值对应的 zeronode 节点类型字节，供 errs.JSONType 取类型名
+func typeOf(x any) byte {
+	if x == nil {
+		return 'l'
+	}
+	if _, ok := x.(json.Number); ok {
+		return 'n'
+	}
+	switch reflect.ValueOf(x).Kind() {
+	case reflect.String:
+		return 's'
+	case reflect.Bool:
+		return 'b'
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return 'n'
+	case reflect.Map, reflect.Struct:
+		return 'o'
+	case reflect.Slice, reflect.Array:
+		return 'a'
+	}
+	return 'l'
+}
+
+// scalarOf 归一化输入：数字返回 (i/u/f 之一, kind)，kind 取值 'i' 'u' 'f' 's' 'b'，其他为 0
+func scalarOf(x any) (i int64, u uint64, f float64, s string, b bool, kind byte) {
+	if n, ok := x.(json.Number); ok {
+		return 0, 0, 0, string(n), false, 'N'
+	}
+	if x == nil {
+		return
+	}
+	rv := reflect.ValueOf(x)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), 0, 0, "", false, 'i'
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return 0, rv.Uint(), 0, "", false, 'u'
+	case reflect.Float32, reflect.Float64:
+		return 0, 0, rv.Float(), "", false, 'f'
+	case reflect.String:
+		return 0, 0, 0, strings.TrimSpace(rv.String()), false, 's'
+	case reflect.Bool:
+		return 0, 0, 0, "", rv.Bool(), 'b'
+	}
+	return
+}
+
+func coerceString(x any) (string, bool) {
+	i, u, f, s, b, kind := scalarOf(x)
+	switch kind {
+	case 'i':
+		return strconv.FormatInt(i, 10), true
+	case 'u':
+		return strconv.FormatUint(u, 10), true
+	case 'f':
+		return strconv.FormatFloat(f, 'f', -1, 64), true
+	case 'b':
+		return strconv.FormatBool(b), true
+	case 'N':
+		return s, true
+	case 's':
+		// 字符串保持原样（不去空白）
+		return reflect.ValueOf(x).String(), true
+	}
+	return "", false
+}
+
+func coerceBool(x any) (bool, bool) {
+	i, u, f, s, b, kind := scalarOf(x)
+	switch kind {
+	case 'b':
+		return b, true
+	case 'i':
+		return i == 1, i == 0 || i == 1
+	case 'u':
+		return u == 1, u <= 1
+	case 'f':
+		return f == 1, f == 0 || f == 1
+	case 's', 'N':
+		switch strings.ToLower(s) {
+		case "true", "1", "yes", "y", "on", "t":
+			return true, true
+		case "false", "0", "no", "n", "off", "f":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func coerceInt(x any, t reflect.Type) (int64, error) {
+	i, u, f, s, b, kind := scalarOf(x)
+	switch kind {
+	case 'i':
+		return i, nil
+	case 'u':
+		if u > math.MaxInt64 {
+			return 0, errs.OutOfRange(strconv.FormatUint(u, 10), t.String())
+		}
+		return int64(u), nil
+	case 'f':
+		return floatToInt(f, t)
+	case 'b':
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	case 's', 'N':
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err == nil {
+			return v, nil
+		}
+		if isRangeErr(err) {
+			return 0, errs.OutOfRange(s, t.String())
+		}
+		if n, ok, over := parseIntegral(s); over || ok && !n.IsInt64() {
+			return 0, errs.OutOfRange(s, t.String())
+		} else if ok {
+			return n.Int64(), nil
+		}
+	}
+	return 0, mismatch(x, t)
+}
+
+func coerceUint(x any, t reflect.Type) (uint64, error) {
+	i, u, f, s, b, kind := scalarOf(x)
+	switch kind {
+	case 'i':
+		if i < 0 {
+			return 0, errs.OutOfRange(strconv.FormatInt(i, 10), t.String())
+		}
+		return uint64(i), nil
+	case 'u':
+		return u, nil
+	case 'f':
+		if f < 0 || f >= 1<<64 {
+			return 0, errs.OutOfRange(strconv.FormatFloat(f, 'g', -1, 64), t.String())
+		}
+		if f != math.Trunc(f) {
+			return 0, mismatch(x, t)
+		}
+		return uint64(f), nil
+	case 'b':
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	case 's', 'N':
+		if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return v, nil
+		}
+		// 负数、超过 uint64 的整数与整值数字字符串
+		if n, ok, over := parseIntegral(s); over || ok && (n.Sign() < 0 || !n.IsUint64()) {
+			return 0, errs.OutOfRange(s, t.String())
+		} else if ok {
+			return n.Uint64(), nil
+		}
+	}
+	return 0, mismatch(x, t)
+}
+
+// parseIntegral 精确解析整值的数字字符串（如 "3.0"、"1e3"），不经 float64 舍入：
+// 非整值或非数字返回 ok=false；量级达到 2^64 时返回 over=true
+func parseIntegral(s string) (n *big.Int, ok, over bool) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, false, isRangeErr(err) && math.IsInf(f, 0)
+	}
+	if !math.IsInf(f, 0) && math.Abs(f) >= 1<<64 {
+		return nil, false, true
+	}
+	var q big.Rat
+	if _, ok := q.SetString(s); !ok || !q.IsInt() {
+		return nil, false, false
+	}
+	return q.Num(), true, false
+}
+
+func floatToInt(f float64, t reflect.Type) (int64, error) {
+	if f != math.Trunc(f) || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, &errs.ErrTypeMismatch{Want: t.String(), Got: "number"}
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, errs.OutOfRange(strconv.FormatFloat(f, 'g', -1, 64), t.String())
+	}
+	return int64(f), nil
+}
+
+func coerceFloat(x any, t reflect.Type) (float64, error) {
+	i, u, f, s, b, kind := scalarOf(x)
+	switch kind {
+	case 'i':
+		return float64(i), nil
+	case 'u':
+		return float64(u), nil
+	case 'f':
+		return f, nil
+	case 'b':
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	case 's', 'N':
+		v, err := strconv.ParseFloat(s, 64)
+		if err == nil && !math.IsInf(v, 0) && !math.IsNaN(v) {
+			return v, nil
+		}
+		if isRangeErr(err) {
+			return 0, errs.OutOfRange(s, t.String())
+		}
+	}
+	return 0, mismatch(x, t)
+}
+
+func isRangeErr(err error) bool {
+	ne, ok := err.(*strconv.NumError)
+	return ok && ne.Err == strconv.ErrRange
+}
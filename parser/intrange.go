@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"math"
+	"strconv"
+)
+
+// ParseUintFastBytes 快速 uint64 解析（带溢出检查），不接受符号
+func ParseUintFastBytes(b []byte) (uint64, bool) {
+	if len(b) == 0 {
+		return 0, false
+	}
+	var n uint64
+	for i := 0; i < len(b); i++ {
+		c := b[i] - '0'
+		if c > 9 {
+			return 0, false
+		}
+		d := uint64(c)
+		if n > (math.MaxUint64-d)/10 {
+			return 0, false
+		}
+		n = n*10 + d
+	}
+	return n, true
+}
+
+// ParseUintFast 同 ParseUintFastBytes 的 string 版本
+func ParseUintFast(s string) (uint64, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		c := s[i] - '0'
+		if c > 9 {
+			return 0, false
+		}
+		d := uint64(c)
+		if n > (math.MaxUint64-d)/10 {
+			return 0, false
+		}
+		n = n*10 + d
+	}
+	return n, true
+}
+
+// IntegerBytesAs 将整数字面量解析为整数类型 T，带溢出/范围检查。
+// T 不是整数类型、字面量非法或超出 T 的范围时返回 false。
+func IntegerBytesAs[T any](b []byte) (T, bool) {
+	var zero T
+	if len(b) > 0 && b[0] == '-' {
+		i, ok := ParseIntFastBytes(b)
+		if !ok {
+			return zero, false
+		}
+		return IntAs[T](i)
+	}
+	u, ok := ParseUintFastBytes(b)
+	if !ok {
+		return zero, false
+	}
+	return UintAs[T](u)
+}
+
+// IntAs 将 int64 转为整数类型 T，超出范围返回 false
+func IntAs[T any](v int64) (T, bool) {
+	var out T
+	switch p := any(&out).(type) {
+	case *int:
+		if v < math.MinInt || v > math.MaxInt {
+			return out, false
+		}
+		*p = int(v)
+	case *int8:
+		if v < math.MinInt8 || v > math.MaxInt8 {
+			return out, false
+		}
+		*p = int8(v)
+	case *int16:
+		if v < math.MinInt16 || v > math.MaxInt16 {
+			return out, false
+		}
+		*p = int16(v)
+	case *int32:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return out, false
+		}
+		*p = int32(v)
+	case *int64:
+		*p = v
+	default:
+		if v < 0 {
+			return out, false
+		}
+		return UintAs[T](uint64(v))
+	}
+	return out, true
+}
+
+// UintAs 将 uint64 转为整数类型 T，超出范围返回 false
+func UintAs[T any](v uint64) (T, bool) {
+	var out T
+	switch p := any(&out).(type) {
+	case *uint:
+		if v > math.MaxUint {
+			return out, false
+		}
+		*p = uint(v)
+	case *uint8:
+		if v > math.MaxUint8 {
+			return out, false
+		}
+		*p = uint8(v)
+	case *uint16:
+		if v > math.MaxUint16 {
+			return out, false
+		}
+		*p = uint16(v)
+	case *uint32:
+		if v > math.MaxUint32 {
+			return out, false
+		}
+		*p = uint32(v)
+	case *uint64:
+		*p = v
+	case *uintptr:
+		if uint64(uintptr(v)) != v {
+			return out, false
+		}
+		*p = uintptr(v)
+	case *int, *int8, *int16, *int32, *int64:
+		if v > math.MaxInt64 {
+			return out, false
+		}
+		return IntAs[T](int64(v))
+	default:
+		return out, false
+	}
+	return out, true
+}
+
+// ParseFloat32Bytes 解析为 float32，超出 float32 范围返回 false
+func ParseFloat32Bytes(b []byte) (float32, bool) {
+	f, err := strconv.ParseFloat(string(b), 32)
+	return float32(f), err == nil
+}
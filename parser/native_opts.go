@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"encoding/json"
+
+	"github.com/icloudza/gcjson/convert"
+	"github.com/icloudza/gcjson/zeronode"
+	"github.com/tidwall/gjson"
+)
+
+// NativeOptions 控制 ToNativeWith / ToNativeBytesWith 的转换方式。零值与 ToNative 相同。
+type NativeOptions struct {
+	// UseNumber 为 true 时数字一律返回 json.Number（原文），
+	// 不再转为 int64 / uint64 / float64，适合订单号、金额等不能丢精度的场景
+	UseNumber bool
+	// Ordered 为 true 时对象转为 *OrderedMap，保留原始键顺序与重复键
+	Ordered bool
+	// Limits 本次转换使用的上限，nil 时用全局 zeronode.GetLimits()（不含 MaxBytes）
+	Limits *zeronode.Limits
+}
+
+// dupErr 重复键策略为 DupError 时检查整个输入：与 Node.Get 对重复键返回空节点一致，
+// 不静默取其中一个值
+func dupErr(b []byte) error {
+	if zeronode.GetDupPolicy() != zeronode.DupError {
+		return nil
+	}
+	return zeronode.CheckDuplicates(b)
+}
+
+// budget 全局上限中的 MaxBytes 只约束查询入口（根包、raw），物化不再重复检查；
+// 显式传入的 o.Limits 全部生效
+func (o *NativeOptions) budget(size int) (zeronode.Budget, error) {
+	l := zeronode.GetLimits()
+	l.MaxBytes = 0
+	if o.Limits != nil {
+		l = *o.Limits
+	}
+	var bud zeronode.Budget
+	bud.Reset(l)
+	return bud, l.CheckSize(size)
+}
+
+// ToNativeWith 同 ToNative，按 o 转换
+func ToNativeWith(r gjson.Result, o NativeOptions) any {
+	v, _ := ToNativeErr(r, o)
+	return v
+}
+
+// ToNativeErr 同 ToNativeWith，超出上限时返回 *zeronode.LimitError；
+// 重复键策略为 zeronode.DupError 且含重复键时返回 errs.ErrDuplicateKey
+func ToNativeErr(r gjson.Result, o NativeOptions) (any, error) {
+	bud, err := o.budget(len(r.Raw))
+	if err != nil {
+		return nil, err
+	}
+	if err := dupErr(convert.UnsafeStringToBytes(r.Raw)); err != nil {
+		return nil, err
+	}
+	v := resultNative(r, &o, &bud, 0)
+	if err := bud.Err(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// resultNative depth 为包含 r 的容器层数
+func resultNative(r gjson.Result, o *NativeOptions, bud *zeronode.Budget, depth int) any {
+	switch r.Type {
+	case gjson.Null:
+		bud.Value(depth, -1, -1)
+		return nil
+	case gjson.True, gjson.False:
+		bud.Value(depth, -1, -1)
+		return r.Bool()
+	case gjson.String:
+		bud.Value(depth, len(r.Raw)-2, -1)
+		return r.String()
+	case gjson.Number:
+		bud.Value(depth, -1, -1)
+		if o.UseNumber {
+			return json.Number(r.Raw)
+		}
+		if IsIntegerLiteral(r.Raw) {
+			if i, ok := ParseIntFast(r.Raw); ok {
+				return i
+			}
+			if u, ok := ParseUintFast(r.Raw); ok {
+				return u
+			}
+		} else if f, ok := ParseFloat64(r.Raw); ok {
+			return f
+		}
+		return r.Raw
+	case gjson.JSON:
+		if bud.Value(depth+1, -1, -1) != nil {
+			return nil
+		}
+		if len(r.Raw) > 0 && r.Raw[0] == '[' {
+			out := make([]any, 0, 8)
+			r.ForEach(func(_, v gjson.Result) bool {
+				if bud.Members('a', len(out)+1, -1) != nil {
+					return false
+				}
+				out = append(out, resultNative(v, o, bud, depth+1))
+				return bud.Err() == nil
+			})
+			return out
+		}
+		var om *OrderedMap
+		var m map[string]any
+		if o.Ordered {
+			om = NewOrderedMap(8)
+		} else {
+			m = make(map[string]any, 8)
+		}
+		n := 0
+		r.ForEach(func(k, v gjson.Result) bool {
+			n++
+			if bud.Members('o', n, -1) != nil || bud.Key(len(k.Raw)-2, -1) != nil {
+				return false
+			}
+			ks := k.String()
+			if om != nil {
+				om.Append(ks, resultNative(v, o, bud, depth+1))
+			} else if !skipDup(m, ks) {
+				m[ks] = resultNative(v, o, bud, depth+1)
+			}
+			return bud.Err() == nil
+		})
+		if om != nil {
+			return om
+		}
+		return m
+	default:
+		bud.Value(depth, -1, -1)
+		return r.Value()
+	}
+}
+
+// ToNativeBytesWith 同 ToNativeBytes，按 o 转换
+func ToNativeBytesWith(b []byte, o NativeOptions) any {
+	v, _ := ToNativeBytesErr(b, o)
+	return v
+}
+
+// ToNativeBytesErr 同 ToNativeBytesWith，超出上限时返回 *zeronode.LimitError（Offset 相对于 b）；
+// 重复键策略为 zeronode.DupError 且含重复键时返回 errs.ErrDuplicateKey
+func ToNativeBytesErr(b []byte, o NativeOptions) (any, error) {
+	bud, err := o.budget(len(b))
+	if err != nil {
+		return nil, err
+	}
+	if err := dupErr(b); err != nil {
+		return nil, err
+	}
+	v := nodeNative(zeronode.FromBytes(b), &o, &bud, 0)
+	if err := bud.Err(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// nodeNative depth 为包含 n 的容器层数
+func nodeNative(n zeronode.Node, o *NativeOptions, bud *zeronode.Budget, depth int) any {
+	switch n.Type() {
+	case 'l': // null
+		bud.Value(depth, -1, n.Offset())
+		return nil
+	case 'b': // bool
+		bud.Value(depth, -1, n.Offset())
+		v, _ := n.Bool()
+		return v
+	case 's': // string
+		bud.Value(depth, len(n.Raw())-2, n.Offset())
+		// 这里返回已反转义的 string（有分配，便于通用）
+		return n.UnescapedString()
+	case 'n': // number
+		bud.Value(depth, -1, n.Offset())
+		if o.UseNumber {
+			v, _ := n.Number()
+			return v
+		}
+		raw := n.Raw()
+		// 尽量走整数快路径；整数溢出不再退化为 float64
+		if IsIntegerLiteralBytes(raw) {
+			if i, ok := ParseIntFastBytes(raw); ok {
+				return i
+			}
+			if u, ok := ParseUintFastBytes(raw); ok {
+				return u
+			}
+			return string(raw)
+		}
+		if f, ok := ParseFloat64Bytes(raw); ok {
+			return f
+		}
+		// 解析失败就原样返回字符串（理论上不该走到）
+		return string(raw)
+	case 'a': // array
+		if bud.Value(depth+1, -1, n.Offset()) != nil {
+			return nil
+		}
+		out := make([]any, 0, 8)
+		n.ForEachArray(func(i int, v zeronode.Node) bool {
+			if bud.Members('a', i+1, v.Offset()) != nil {
+				return false
+			}
+			out = append(out, nodeNative(v, o, bud, depth+1))
+			return bud.Err() == nil
+		})
+		return out
+	case 'o': // object
+		if bud.Value(depth+1, -1, n.Offset()) != nil {
+			return nil
+		}
+		var om *OrderedMap
+		var m map[string]any
+		if o.Ordered {
+			om = NewOrderedMap(8)
+		} else {
+			m = make(map[string]any, 8)
+		}
+		cnt := 0
+		n.ForEachObject(func(k []byte, v zeronode.Node) bool {
+			cnt++
+			if bud.Members('o', cnt, v.Offset()) != nil || bud.Key(len(k), v.Offset()) != nil {
+				return false
+			}
+			ks := string(k)
+			if om != nil {
+				om.Append(ks, nodeNative(v, o, bud, depth+1))
+			} else if !skipDup(m, ks) {
+				m[ks] = nodeNative(v, o, bud, depth+1)
+			}
+			return bud.Err() == nil
+		})
+		if om != nil {
+			return om
+		}
+		return m
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,221 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/icloudza/gcjson/errs"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// Member OrderedMap 中的一个键值对
+type Member struct {
+	Key   string
+	Value any
+}
+
+// OrderedMap 保留原始键顺序（包括重复键）的 JSON 对象。
+// 由 NativeOptions.Ordered 产生，嵌套对象同样为 *OrderedMap；
+// MarshalJSON 按原顺序输出，适合展示上游报文或重新签名。
+//
+// 重复键全部保留：Get / Set 按全局重复键策略（zeronode.SetDupPolicy）取第一个或最后一个，
+// DupError 时重复的键视为不存在（同 Node.Get）；Range / MarshalJSON 按原样逐个输出。
+// 零值可直接使用。
+type OrderedMap struct {
+	members []Member
+	index   map[string]int // 键 -> members 中最后一次出现的位置
+	dup     bool           // 是否存在重复键
+}
+
+// NewOrderedMap 创建预分配 n 个成员的 OrderedMap
+func NewOrderedMap(n int) *OrderedMap {
+	return &OrderedMap{members: make([]Member, 0, n), index: make(map[string]int, n)}
+}
+
+// Len 成员个数（重复键分别计数）
+func (m *OrderedMap) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.members)
+}
+
+// Get 返回 key 对应的值；重复键按全局策略取第一个或最后一个，DupError 时返回 (nil, false)
+func (m *OrderedMap) Get(key string) (any, bool) {
+	if m == nil {
+		return nil, false
+	}
+	i, ok := m.pos(key)
+	if !ok {
+		return nil, false
+	}
+	return m.members[i].Value, true
+}
+
+// pos 返回 key 生效成员的位置；DupError 时重复的键没有生效成员
+func (m *OrderedMap) pos(key string) (int, bool) {
+	i, ok := m.index[key]
+	if !ok || !m.dup {
+		return i, ok
+	}
+	switch zeronode.GetDupPolicy() {
+	case zeronode.DupLast:
+		return i, true
+	case zeronode.DupError:
+		for j := range m.members {
+			if j != i && m.members[j].Key == key {
+				return -1, false
+			}
+		}
+		return i, true
+	}
+	for j := range m.members {
+		if m.members[j].Key == key {
+			return j, true
+		}
+	}
+	return i, true
+}
+
+// Has 是否包含 key
+func (m *OrderedMap) Has(key string) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set 设置 key：已存在则原位替换生效的那个成员，否则追加到末尾；
+// DupError 时重复的键先全部删除再追加
+func (m *OrderedMap) Set(key string, v any) {
+	if i, ok := m.pos(key); ok {
+		m.members[i].Value = v
+		return
+	}
+	m.Delete(key)
+	m.Append(key, v)
+}
+
+// Append 无条件追加一个成员，允许重复键
+func (m *OrderedMap) Append(key string, v any) {
+	if m.index == nil {
+		m.index = make(map[string]int, 8)
+	}
+	if _, ok := m.index[key]; ok {
+		m.dup = true
+	}
+	m.index[key] = len(m.members)
+	m.members = append(m.members, Member{Key: key, Value: v})
+}
+
+// Delete 删除 key 的所有成员
+func (m *OrderedMap) Delete(key string) {
+	if _, ok := m.index[key]; !ok {
+		return
+	}
+	out := m.members[:0]
+	for _, e := range m.members {
+		if e.Key != key {
+			out = append(out, e)
+		}
+	}
+	clear(m.members[len(out):])
+	m.members = out
+	m.reindex()
+}
+
+func (m *OrderedMap) reindex() {
+	clear(m.index)
+	m.dup = false
+	for i, e := range m.members {
+		if _, ok := m.index[e.Key]; ok {
+			m.dup = true
+		}
+		m.index[e.Key] = i
+	}
+}
+
+// Keys 按原顺序返回所有键（含重复）
+func (m *OrderedMap) Keys() []string {
+	out := make([]string, m.Len())
+	for i := range out {
+		out[i] = m.members[i].Key
+	}
+	return out
+}
+
+// Members 按原顺序返回所有成员的拷贝
+func (m *OrderedMap) Members() []Member {
+	if m == nil {
+		return nil
+	}
+	return append([]Member(nil), m.members...)
+}
+
+// Range 按原顺序遍历，fn 返回 false 时停止
+func (m *OrderedMap) Range(fn func(key string, v any) bool) {
+	if m == nil {
+		return
+	}
+	for _, e := range m.members {
+		if !fn(e.Key, e.Value) {
+			return
+		}
+	}
+}
+
+// Map 转为普通 map（浅转换，嵌套的 *OrderedMap 保持不变；重复键按全局策略取值，
+// DupError 时存在重复键则返回 nil）
+func (m *OrderedMap) Map() map[string]any {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]any, len(m.index))
+	for k := range m.index {
+		i, ok := m.pos(k)
+		if !ok {
+			return nil
+		}
+		out[k] = m.members[i].Value
+	}
+	return out
+}
+
+// MarshalJSON 按原顺序输出，字符串不做 HTML 转义。
+// 经 json.Marshal 调用时标准库仍会转义 <>&，需要与原文一致时直接调用本方法。
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	buf.WriteByte('{')
+	for i, e := range m.members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(e.Key); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1) // Encode 末尾的换行
+		buf.WriteByte(':')
+		if err := enc.Encode(e.Value); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON 解析 JSON 对象，保留键顺序；嵌套对象同样为 *OrderedMap
+func (m *OrderedMap) UnmarshalJSON(b []byte) error {
+	if zeronode.FromBytes(b).Type() != 'o' {
+		return errs.InvalidJSON("OrderedMap requires a JSON object")
+	}
+	v, err := ToNativeBytesErr(b, NativeOptions{Ordered: true})
+	if err != nil {
+		return err
+	}
+	*m = *v.(*OrderedMap)
+	return nil
+}
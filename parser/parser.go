@@ -3,6 +3,7 @@ package parser
 import (
 	"strconv"
 
+	"github.com/icloudza/gcjson/zeronode"
 	"github.com/tidwall/gjson"
 )
 
@@ -30,22 +31,26 @@ func ParseIntFast(s string) (int64, bool) {
 			return 0, false
 		}
 	}
-	var n int64
+	limit := uint64(1<<63 - 1)
+	if neg {
+		limit = 1 << 63
+	}
+	var n uint64
 	for ; i < len(s); i++ {
 		c := s[i] - '0'
 		if c > 9 {
 			return 0, false
 		}
-		d := int64(c)
-		if n > (9223372036854775807-d)/10 {
+		d := uint64(c)
+		if n > (limit-d)/10 {
 			return 0, false
 		}
 		n = n*10 + d
 	}
 	if neg {
-		n = -n
+		return int64(-n), true
 	}
-	return n, true
+	return int64(n), true
 }
 
 func ParseFloat64(s string) (float64, bool) {
@@ -53,44 +58,19 @@ func ParseFloat64(s string) (float64, bool) {
 	return f, err == nil
 }
 
+// ToNative 将 gjson.Result 转为 Go 原生类型，规则同 ToNativeBytes。
+// 超出全局 zeronode.Limits、或重复键策略为 DupError 且含重复键时返回 nil，需要错误原因时用 ToNativeErr。
 func ToNative(r gjson.Result) any {
-	switch r.Type {
-	case gjson.Null:
-		return nil
-	case gjson.True, gjson.False:
-		return r.Bool()
-	case gjson.String:
-		return r.String()
-	case gjson.Number:
-		if IsIntegerLiteral(r.Raw) {
-			if i, ok := ParseIntFast(r.Raw); ok {
-				return i
-			}
-		} else {
-			if f, ok := ParseFloat64(r.Raw); ok {
-				return f
-			}
-		}
-		return r.Raw
-	case gjson.JSON:
-		raw := r.Raw
-		if len(raw) > 0 && raw[0] == '[' {
-			arr := r.Array()
-			out := make([]any, 0, len(arr))
-			for _, it := range arr {
-				out = append(out, ToNative(it))
-			}
-			return out
-		}
-		m := make(map[string]any, 8)
-		r.ForEach(func(k, v gjson.Result) bool {
-			m[k.String()] = ToNative(v)
-			return true
-		})
-		return m
-	default:
-		return r.Value()
+	v, _ := ToNativeErr(r, NativeOptions{})
+	return v
+}
+
+// skipDup 报告 k 已在 m 中且按全局重复键策略应保留已有值（默认第一个生效）
+func skipDup(m map[string]any, k string) bool {
+	if _, ok := m[k]; !ok {
+		return false
 	}
+	return zeronode.GetDupPolicy() != zeronode.DupLast
 }
 
 func ToNativeTyped[T any](v any) (T, bool) {
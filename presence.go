@@ -0,0 +1,79 @@
+package gcjson
+
+import (
+	"reflect"
+
+	"github.com/icloudza/gcjson/parser"
+	"github.com/tidwall/gjson"
+)
+
+// Presence 路径上值的三种状态
+type Presence uint8
+
+const (
+	Missing Presence = iota // 路径不存在
+	Null                    // 显式 null
+	Present                 // 有值
+)
+
+var presenceNames = [...]string{"missing", "null", "present"}
+
+func (p Presence) String() string {
+	if int(p) < len(presenceNames) {
+		return presenceNames[p]
+	}
+	return "presence(?)"
+}
+
+// Lookup 返回 path 处的原生值及其状态，区分“缺失”与“null”（Any 对两者都返回 nil）。
+// Go 值输入中 nil 指针、nil map/切片/接口按 null 处理（与序列化结果一致）。
+//
+//	v, p := gcjson.Lookup(body, "user.email")
+//	switch p {
+//	case gcjson.Missing: // 未传，保持原值
+//	case gcjson.Null:    // 显式清空
+//	case gcjson.Present: // 更新为 v
+//	}
+func Lookup(v any, path string) (any, Presence) {
+	if x, found, handled := walkValue(v, path); handled {
+		switch {
+		case !found:
+			return nil, Missing
+		case isNilValue(x):
+			return nil, Null
+		}
+		return x, Present
+	}
+	r, err := GetAny(v, path)
+	if err != nil || !r.Exists() {
+		return nil, Missing
+	}
+	if r.Type == gjson.Null {
+		return nil, Null
+	}
+	return parser.ToNative(r), Present
+}
+
+// Exists 路径是否存在（值为 null 也算存在）
+func Exists(v any, path string) bool {
+	_, p := Lookup(v, path)
+	return p != Missing
+}
+
+// IsNull 路径上的值是否为显式 null（缺失返回 false）
+func IsNull(v any, path string) bool {
+	_, p := Lookup(v, path)
+	return p == Null
+}
+
+func isNilValue(x any) bool {
+	if x == nil {
+		return true
+	}
+	rv := reflect.ValueOf(x)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -2,42 +2,62 @@ package raw
 
 import (
 	"encoding/json"
-	"errors"
 
+	"github.com/icloudza/gcjson/errs"
 	"github.com/icloudza/gcjson/zeronode"
 )
 
-var MaxJSONSize = 10 << 20 // 10MB
+// MaxJSONSize 输入大小上限，默认 10MB（zeronode.DefaultMaxBytes）。
+// 改为其他值后对 raw 与根包查询总是生效，覆盖全局 Limits.MaxBytes；0 表示不限制。
+//
+// Deprecated: 使用 zeronode.SetLimits 设置 Limits.MaxBytes。
+var MaxJSONSize = zeronode.DefaultMaxBytes
+
+// SizeLimit 返回 raw 与根包查询当前的输入大小上限：MaxJSONSize 被改为非默认值时以它为准，
+// 否则为全局 zeronode.GetLimits().MaxBytes（0 表示不限制）
+func SizeLimit() int {
+	if MaxJSONSize != zeronode.DefaultMaxBytes {
+		return MaxJSONSize
+	}
+	return zeronode.GetLimits().MaxBytes
+}
+
+// CheckSize 按 SizeLimit 检查 n 字节的输入，超限返回可用 errors.Is(err, errs.ErrTooLarge) 判断的错误
+func CheckSize(n int) error {
+	return zeronode.Limits{MaxBytes: SizeLimit()}.CheckSize(n)
+}
 
 func From(v any) (zeronode.Node, error) {
 	switch x := v.(type) {
 	case nil:
-		return zeronode.Node{}, errors.New("nil input")
+		return zeronode.Node{}, errs.InvalidJSON("nil input")
 	case []byte:
-		if len(x) > MaxJSONSize {
-			return zeronode.Node{}, errors.New("json too large")
+		if err := CheckSize(len(x)); err != nil {
+			return zeronode.Node{}, err
 		}
 		if !quickValidateJSON(x) {
-			return zeronode.Node{}, errors.New("invalid json")
+			return zeronode.Node{}, errs.InvalidJSON("unexpected first character")
 		}
 		return zeronode.FromBytes(x), nil
 	case string:
-		if len(x) > MaxJSONSize {
-			return zeronode.Node{}, errors.New("json too large")
+		if err := CheckSize(len(x)); err != nil {
+			return zeronode.Node{}, err
 		}
 		if !quickValidateJSON([]byte(x)) {
-			return zeronode.Node{}, errors.New("invalid json")
+			return zeronode.Node{}, errs.InvalidJSON("unexpected first character")
 		}
 		return zeronode.FromBytes([]byte(x)), nil
 	case zeronode.Node:
 		return x, nil
+	case zeronode.Partial:
+		return x.Node, nil
 	default:
 		b, err := json.Marshal(v)
 		if err != nil {
 			return zeronode.Node{}, err
 		}
-		if len(b) > MaxJSONSize {
-			return zeronode.Node{}, errors.New("json too large")
+		if err := CheckSize(len(b)); err != nil {
+			return zeronode.Node{}, err
 		}
 		return zeronode.FromBytes(b), nil
 	}
@@ -2,6 +2,7 @@ package raw
 
 import (
 	"github.com/icloudza/gcjson/convert"
+	"github.com/icloudza/gcjson/errs"
 	pathplan "github.com/icloudza/gcjson/internal"
 	"github.com/icloudza/gcjson/zeronode"
 )
@@ -189,3 +190,34 @@ done:
 	}
 	return b[start:end]
 }
+
+// GetBytesErr 同 GetBytes，但用错误区分失败原因：
+// errs.ErrNotFound、errs.ErrInvalidJSON、errs.ErrTooLarge，
+// 以及重复键策略为 zeronode.DupError 时的 errs.ErrDuplicateKey（可用 errors.Is 判断）。
+func GetBytesErr(v any, path string) ([]byte, error) {
+	b, err := convert.From(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckSize(len(b)); err != nil {
+		return nil, err
+	}
+	if zeronode.GetDupPolicy() == zeronode.DupError {
+		if err := zeronode.CheckDuplicates(b); err != nil {
+			return nil, err
+		}
+	}
+	if n, ok := findByPath(b, path); ok {
+		return trimSpaceBytes(n.Raw()), nil
+	}
+	if err := zeronode.Validate(b); err != nil {
+		return nil, errs.InvalidJSON(err.Error())
+	}
+	return nil, errs.NotFound(path)
+}
+
+// GetErr 同 GetBytesErr，返回字符串拷贝
+func GetErr(v any, path string) (string, error) {
+	bs, err := GetBytesErr(v, path)
+	return string(bs), err
+}
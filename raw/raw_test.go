@@ -1,10 +1,14 @@
 package raw_test
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/icloudza/gcjson/errs"
 	"github.com/icloudza/gcjson/raw"
+	"github.com/icloudza/gcjson/zeronode"
 )
 
 var sampleJSON = []byte(`{
@@ -89,3 +93,28 @@ func Benchmark_GetBytesByPlan(b *testing.B) {
 		_, _ = raw.GetBytesByPlan(doc, pl)
 	}
 }
+
+func TestGetPartial(t *testing.T) {
+	p, _ := zeronode.ParsePartial([]byte(`{"a":{"b":1},"c":"xy`))
+	if b, ok := raw.GetBytes(p, "a.b"); !ok || string(b) != "1" {
+		t.Fatalf("GetBytes(partial) = %s, %v", b, ok)
+	}
+	if n, err := raw.From(p); err != nil || n.Get("c").String() != "xy" {
+		t.Fatalf("From(partial) = %v", err)
+	}
+}
+
+func TestGetBytesErr(t *testing.T) {
+	if b, err := raw.GetBytesErr(`{"a":{"b":[1,2]}}`, "a.b.1"); err != nil || string(b) != "2" {
+		t.Fatalf("GetBytesErr = %s, %v", b, err)
+	}
+	if _, err := raw.GetBytesErr(`{"a":1}`, "x"); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+	if _, err := raw.GetBytesErr(`{"a":`, "x"); !errors.Is(err, errs.ErrInvalidJSON) {
+		t.Fatalf("want ErrInvalidJSON, got %v", err)
+	}
+	if _, err := raw.From(strings.Repeat(" ", raw.SizeLimit()+1)); !errors.Is(err, errs.ErrTooLarge) {
+		t.Fatalf("want ErrTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+package gcjson
+
+import "github.com/icloudza/gcjson/zeronode"
+
+// Fix Repair 所做的一处修正
+type Fix = zeronode.Fix
+
+// Repair 将单引号、裸键、尾逗号、注释、NaN、True/None 等“近似 JSON”改写为合法 JSON，
+// 并报告每处修正在输入中的偏移。结果可直接交给 raw、zeronode、structfast 使用。
+//
+//	fixed, fixes, err := gcjson.Repair([]byte(`{name: 'Bob', ok: True,}`))
+//	// fixed => {"name": "Bob", "ok": true}
+func Repair(b []byte) ([]byte, []Fix, error) { return zeronode.Repair(b) }
@@ -0,0 +1,70 @@
+package gcjson
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/icloudza/gcjson/stream"
+	"github.com/icloudza/gcjson/structfast"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+var errNotArray = errors.New("gcjson: stream target is not an array")
+
+// StreamArray 从 r 中流式读取 path 处数组的每个元素并回调 fn，fn 返回 false 时停止。
+// 只读取到目标数组为止，内存占用取决于单个元素大小；n 仅在回调期间有效。
+// path 为以 '.' 分隔的对象键或数组下标，空字符串表示根即数组。
+//
+//	err := gcjson.StreamArray(f, "data.items", func(i int, n zeronode.Node) bool {
+//	    fmt.Println(n.Get("id").Raw())
+//	    return true
+//	})
+func StreamArray(r io.Reader, path string, fn func(i int, n zeronode.Node) bool) error {
+	tz := stream.NewTokenizer(r)
+	var keys []string
+	if path != "" {
+		keys = strings.Split(path, ".")
+	}
+	if err := tz.Seek(keys...); err != nil {
+		return err
+	}
+	tok, err := tz.Next()
+	if err != nil {
+		return err
+	}
+	if tok.Kind != stream.ArrayBegin {
+		return errNotArray
+	}
+	for i := 0; tz.More(); i++ {
+		raw, err := tz.ReadValue()
+		if err != nil {
+			return err
+		}
+		if !fn(i, zeronode.FromBytes(raw)) {
+			return nil
+		}
+	}
+	// 消费 ']'，同时暴露 More 期间遇到的错误
+	_, err = tz.Next()
+	return err
+}
+
+// StreamArrayAs 同 StreamArray，但每个元素用 structfast 解码为 T。
+// 解码失败时停止并返回带元素下标的错误。
+func StreamArrayAs[T any](r io.Reader, path string, fn func(i int, v T) bool) error {
+	var derr error
+	err := StreamArray(r, path, func(i int, n zeronode.Node) bool {
+		var v T
+		if e := structfast.DecodeValue(n, &v); e != nil {
+			derr = fmt.Errorf("gcjson: element %d: %w", i, e)
+			return false
+		}
+		return fn(i, v)
+	})
+	if err != nil {
+		return err
+	}
+	return derr
+}
@@ -0,0 +1,624 @@
+// Package stream 提供基于 io.Reader 的增量 JSON 解析，内存占用与文档大小无关。
+package stream
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/icloudza/gcjson/errs"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// Kind 词法单元类型
+type Kind uint8
+
+const (
+	Invalid Kind = iota
+	ObjectBegin
+	ObjectEnd
+	ArrayBegin
+	ArrayEnd
+	Key
+	String
+	Number
+	Bool
+	Null
+)
+
+var kindNames = [...]string{"invalid", "{", "}", "[", "]", "key", "string", "number", "bool", "null"}
+
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Token 词法单元。Raw 指向 Tokenizer 的内部缓冲（字符串含引号），
+// 仅在下一次 Next 之前有效；需要保留时请自行拷贝。
+type Token struct {
+	Kind   Kind
+	Raw    []byte
+	Offset int64 // Raw 在输入流中的字节偏移
+}
+
+// Text 返回 Key / String 解码后的文本（处理转义），其他类型返回原始文本
+func (t Token) Text() string {
+	if t.Kind == Key || t.Kind == String {
+		return zeronode.FromBytes(t.Raw).UnescapedString()
+	}
+	return string(t.Raw)
+}
+
+// Int 将 Number 解析为 int64
+func (t Token) Int() (int64, bool) {
+	if t.Kind != Number {
+		return 0, false
+	}
+	return zeronode.FromBytes(t.Raw).Int()
+}
+
+// Float 将 Number 解析为 float64
+func (t Token) Float() (float64, bool) {
+	if t.Kind != Number {
+		return 0, false
+	}
+	return zeronode.FromBytes(t.Raw).Float()
+}
+
+// Bool 返回 Bool 的值
+func (t Token) Bool() bool { return t.Kind == Bool && t.Raw[0] == 't' }
+
+// Node 将标量 Token 包装为 zeronode.Node（与 Raw 共享内存）
+func (t Token) Node() zeronode.Node { return zeronode.FromBytes(t.Raw) }
+
+// 解析器状态：下一个 Token 允许出现的位置
+const (
+	stValue      = iota // 任意值（顶层、冒号后、数组逗号后）
+	stValueOrEnd        // '[' 之后
+	stKey               // 对象逗号后
+	stKeyOrEnd          // '{' 之后
+	stColon             // 键之后
+	stCommaOrEnd        // 容器内值之后
+)
+
+const defaultBufSize = 32 << 10
+
+// ErrNotFound Seek 的路径不存在，即 errs.ErrNotFound
+var ErrNotFound = errs.ErrNotFound
+
+// Tokenizer 拉取式 JSON 词法器。顶层允许多个以空白分隔的值（拼接文档 / NDJSON）。
+//
+//	tz := stream.NewTokenizer(r)
+//	for {
+//	    tok, err := tz.Next()
+//	    if err == io.EOF { break }
+//	    if err != nil { return err }
+//	    ...
+//	}
+type Tokenizer struct {
+	r     io.Reader
+	buf   []byte
+	pos   int
+	end   int
+	off   int64 // buf[0] 在输入流中的偏移
+	rerr  error
+	stack []byte // '{' 或 '['
+	st    int
+	err   error
+	mark  int // ReadValue 期间固定的值起点，fill 不会丢弃其后的数据；-1 表示无
+}
+
+// NewTokenizer 使用默认 32KB 缓冲创建词法器
+func NewTokenizer(r io.Reader) *Tokenizer { return NewTokenizerSize(r, defaultBufSize) }
+
+// NewTokenizerSize 指定初始缓冲大小；单个 Token 超过缓冲时缓冲会自动扩容
+func NewTokenizerSize(r io.Reader, size int) *Tokenizer {
+	if size < 16 {
+		size = 16
+	}
+	return &Tokenizer{r: r, buf: make([]byte, size), mark: -1}
+}
+
+// Depth 当前容器嵌套深度
+func (t *Tokenizer) Depth() int { return len(t.stack) }
+
+// InputOffset 下一个未读字节在输入流中的偏移
+func (t *Tokenizer) InputOffset() int64 { return t.off + int64(t.pos) }
+
+// Next 返回下一个 Token。输入在顶层值之间正常结束时返回 io.EOF；
+// 语法错误返回 *zeronode.SyntaxError（Offset 为流内偏移），之后的调用返回同一错误。
+func (t *Tokenizer) Next() (Token, error) {
+	if t.err != nil {
+		return Token{}, t.err
+	}
+	tok, err := t.next()
+	if err != nil {
+		t.err = err
+	}
+	return tok, err
+}
+
+// More 报告当前数组/对象中是否还有下一个元素；出错时返回 false，错误由后续 Next 返回
+func (t *Tokenizer) More() bool {
+	if t.err != nil {
+		return false
+	}
+	c, err := t.peek()
+	return err == nil && c != ']' && c != '}'
+}
+
+// ReadValue 读取下一个完整的值（标量或整个容器）并返回其原始字节。
+// 返回的切片指向内部缓冲，仅在下一次调用前有效；内存占用取决于单个值的大小。
+func (t *Tokenizer) ReadValue() ([]byte, error) {
+	tok, err := t.Next()
+	if err != nil {
+		return nil, err
+	}
+	switch tok.Kind {
+	case ObjectBegin, ArrayBegin:
+	case ObjectEnd, ArrayEnd, Key:
+		return nil, t.fail(&zeronode.SyntaxError{Offset: int(tok.Offset), Msg: "expected value, got " + tok.Kind.String()})
+	default:
+		return tok.Raw, nil
+	}
+	t.mark = int(tok.Offset - t.off)
+	err = t.Skip()
+	start := t.mark
+	t.mark = -1
+	if err != nil {
+		return nil, err
+	}
+	return t.buf[start:t.pos], nil
+}
+
+// SkipValue 跳过下一个完整的值
+func (t *Tokenizer) SkipValue() error {
+	tok, err := t.Next()
+	if err != nil {
+		return err
+	}
+	switch tok.Kind {
+	case ObjectBegin, ArrayBegin:
+		return t.Skip()
+	case ObjectEnd, ArrayEnd, Key:
+		return t.fail(&zeronode.SyntaxError{Offset: int(tok.Offset), Msg: "expected value, got " + tok.Kind.String()})
+	}
+	return nil
+}
+
+// Seek 沿路径前进到目标值之前：keys 为对象键或数组下标（十进制）。
+// 成功后下一次 Next / ReadValue 返回目标值；路径不存在返回带路径、可用
+// errors.Is(err, ErrNotFound) 判断的错误。
+func (t *Tokenizer) Seek(keys ...string) error {
+	if err := t.seek(keys); err != ErrNotFound {
+		return err
+	}
+	return errs.NotFound(strings.Join(keys, "."))
+}
+
+func (t *Tokenizer) seek(keys []string) error {
+	for _, k := range keys {
+		tok, err := t.Next()
+		if err != nil {
+			return err
+		}
+		switch tok.Kind {
+		case ObjectBegin:
+			if err := t.seekKey(k); err != nil {
+				return err
+			}
+		case ArrayBegin:
+			idx, err := strconv.Atoi(k)
+			if err != nil || idx < 0 {
+				return ErrNotFound
+			}
+			for ; idx > 0; idx-- {
+				if !t.More() {
+					return t.notFound()
+				}
+				if err := t.SkipValue(); err != nil {
+					return err
+				}
+			}
+			if !t.More() {
+				return t.notFound()
+			}
+		default:
+			return ErrNotFound
+		}
+	}
+	return nil
+}
+
+func (t *Tokenizer) seekKey(k string) error {
+	for t.More() {
+		tok, err := t.Next()
+		if err != nil {
+			return err
+		}
+		if keyEquals(tok.Raw, k) {
+			return nil
+		}
+		if err := t.SkipValue(); err != nil {
+			return err
+		}
+	}
+	return t.notFound()
+}
+
+// notFound 区分真正的缺失与 More 因错误返回 false
+func (t *Tokenizer) notFound() error {
+	if t.err != nil {
+		return t.err
+	}
+	if _, err := t.peek(); err != nil {
+		if err == io.EOF {
+			err = t.syntaxErr("unexpected end of input")
+		}
+		return t.fail(err)
+	}
+	return ErrNotFound
+}
+
+func keyEquals(raw []byte, k string) bool {
+	s := raw[1 : len(raw)-1]
+	for _, c := range s {
+		if c == '\\' {
+			return zeronode.FromBytes(raw).UnescapedString() == k
+		}
+	}
+	return string(s) == k
+}
+
+func (t *Tokenizer) fail(err error) error {
+	t.err = err
+	return err
+}
+
+// Skip 在 ObjectBegin / ArrayBegin 之后调用，跳过该容器的剩余部分
+func (t *Tokenizer) Skip() error {
+	d := len(t.stack)
+	for len(t.stack) >= d && d > 0 {
+		if _, err := t.Next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Tokenizer) next() (Token, error) {
+	for {
+		c, err := t.peek()
+		if err != nil {
+			if err == io.EOF && len(t.stack) == 0 && t.st == stValue {
+				return Token{}, io.EOF
+			}
+			if err == io.EOF {
+				return Token{}, t.syntaxErr("unexpected end of input")
+			}
+			return Token{}, err
+		}
+		switch t.st {
+		case stColon:
+			if c != ':' {
+				return Token{}, t.invalid(c)
+			}
+			t.pos++
+			t.st = stValue
+			continue
+		case stCommaOrEnd:
+			top := t.stack[len(t.stack)-1]
+			if c == ',' {
+				t.pos++
+				if top == '{' {
+					t.st = stKey
+				} else {
+					t.st = stValue
+				}
+				continue
+			}
+			if (c == '}' && top == '{') || (c == ']' && top == '[') {
+				return t.close(c), nil
+			}
+			return Token{}, t.invalid(c)
+		case stKeyOrEnd:
+			if c == '}' {
+				return t.close(c), nil
+			}
+			fallthrough
+		case stKey:
+			if c != '"' {
+				return Token{}, t.invalid(c)
+			}
+			tok, err := t.scanString(Key)
+			if err == nil {
+				t.st = stColon
+			}
+			return tok, err
+		case stValueOrEnd:
+			if c == ']' {
+				return t.close(c), nil
+			}
+		}
+		return t.value(c)
+	}
+}
+
+func (t *Tokenizer) value(c byte) (Token, error) {
+	var (
+		tok Token
+		err error
+	)
+	switch {
+	case c == '{' || c == '[':
+		tok = Token{Kind: ObjectBegin, Raw: t.buf[t.pos : t.pos+1], Offset: t.InputOffset()}
+		t.st = stKeyOrEnd
+		if c == '[' {
+			tok.Kind, t.st = ArrayBegin, stValueOrEnd
+		}
+		t.stack = append(t.stack, c)
+		t.pos++
+		return tok, nil
+	case c == '"':
+		tok, err = t.scanString(String)
+	case c == 't':
+		tok, err = t.scanLiteral("true", Bool)
+	case c == 'f':
+		tok, err = t.scanLiteral("false", Bool)
+	case c == 'n':
+		tok, err = t.scanLiteral("null", Null)
+	case c == '-' || (c >= '0' && c <= '9'):
+		tok, err = t.scanNumber()
+	default:
+		return Token{}, t.invalid(c)
+	}
+	if err == nil {
+		t.afterValue()
+	}
+	return tok, err
+}
+
+func (t *Tokenizer) close(c byte) Token {
+	tok := Token{Kind: ObjectEnd, Raw: t.buf[t.pos : t.pos+1], Offset: t.InputOffset()}
+	if c == ']' {
+		tok.Kind = ArrayEnd
+	}
+	t.pos++
+	t.stack = t.stack[:len(t.stack)-1]
+	t.afterValue()
+	return tok
+}
+
+func (t *Tokenizer) afterValue() {
+	if len(t.stack) == 0 {
+		t.st = stValue
+	} else {
+		t.st = stCommaOrEnd
+	}
+}
+
+// peek 跳过空白并返回下一个字节（不消费）
+func (t *Tokenizer) peek() (byte, error) {
+	for {
+		for t.pos < t.end {
+			switch c := t.buf[t.pos]; c {
+			case ' ', '\t', '\n', '\r':
+				t.pos++
+			default:
+				return c, nil
+			}
+		}
+		if _, err := t.fill(); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// fill 把未消费数据移到缓冲开头并继续读取，返回移动的字节数。
+// 缓冲已满（单个 Token 超过缓冲）时扩容。
+func (t *Tokenizer) fill() (int, error) {
+	if t.rerr != nil {
+		return 0, t.rerr
+	}
+	shift := t.pos
+	if t.mark >= 0 && t.mark < shift {
+		shift = t.mark
+	}
+	if shift > 0 {
+		copy(t.buf, t.buf[shift:t.end])
+		t.end -= shift
+		t.pos -= shift
+		t.off += int64(shift)
+		if t.mark >= 0 {
+			t.mark -= shift
+		}
+	}
+	if t.end == len(t.buf) {
+		nb := make([]byte, 2*len(t.buf))
+		copy(nb, t.buf[:t.end])
+		t.buf = nb
+	}
+	for i := 0; i < 100; i++ {
+		n, err := t.r.Read(t.buf[t.end:])
+		t.end += n
+		if err != nil {
+			t.rerr = err
+			if n > 0 {
+				return shift, nil
+			}
+			return shift, err
+		}
+		if n > 0 {
+			return shift, nil
+		}
+	}
+	t.rerr = io.ErrNoProgress
+	return shift, t.rerr
+}
+
+// more 在扫描到缓冲末尾时补充数据；i 为当前扫描位置，返回修正后的位置
+func (t *Tokenizer) more(i int) (int, error) {
+	shift, err := t.fill()
+	i -= shift
+	if err == io.EOF {
+		return i, t.syntaxErrAt(t.end, "unexpected end of input")
+	}
+	return i, err
+}
+
+func (t *Tokenizer) scanString(kind Kind) (Token, error) {
+	i := t.pos + 1
+	for {
+		for i < t.end {
+			c := t.buf[i]
+			switch {
+			case c == '"':
+				tok := Token{Kind: kind, Raw: t.buf[t.pos : i+1], Offset: t.InputOffset()}
+				t.pos = i + 1
+				return tok, nil
+			case c < 0x20:
+				return Token{}, t.syntaxErrAt(i, "control character in string")
+			case c == '\\':
+				n, ok := escapeLen(t.buf[i:t.end])
+				if n == 0 {
+					goto refill
+				}
+				if !ok {
+					return Token{}, t.syntaxErrAt(i, "invalid escape")
+				}
+				i += n
+			default:
+				i++
+			}
+		}
+	refill:
+		var err error
+		if i, err = t.more(i); err != nil {
+			return Token{}, err
+		}
+	}
+}
+
+// escapeLen 返回转义序列长度；数据不足返回 0
+func escapeLen(b []byte) (int, bool) {
+	if len(b) < 2 {
+		return 0, true
+	}
+	switch b[1] {
+	case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+		return 2, true
+	case 'u':
+		if len(b) < 6 {
+			return 0, true
+		}
+		for _, c := range b[2:6] {
+			if !(c >= '0' && c <= '9' || c|0x20 >= 'a' && c|0x20 <= 'f') {
+				return 1, false
+			}
+		}
+		return 6, true
+	}
+	return 1, false
+}
+
+func (t *Tokenizer) scanNumber() (Token, error) {
+	i := t.pos
+	for {
+		for i < t.end && isNumberByte(t.buf[i]) {
+			i++
+		}
+		if i < t.end {
+			break
+		}
+		shift, err := t.fill()
+		i -= shift
+		if err == io.EOF {
+			break // 顶层数字可以在输入末尾结束
+		}
+		if err != nil {
+			return Token{}, err
+		}
+	}
+	raw := t.buf[t.pos:i]
+	if err := zeronode.Validate(raw); err != nil {
+		return Token{}, t.syntaxErrAt(t.pos, "invalid number")
+	}
+	if err := t.checkDelim(i); err != nil {
+		return Token{}, err
+	}
+	tok := Token{Kind: Number, Raw: raw, Offset: t.InputOffset()}
+	t.pos = i
+	return tok, nil
+}
+
+func (t *Tokenizer) scanLiteral(lit string, kind Kind) (Token, error) {
+	// 多读一个字节用于分隔符检查
+	for t.end-t.pos <= len(lit) {
+		if _, err := t.fill(); err == io.EOF {
+			break
+		} else if err != nil {
+			return Token{}, err
+		}
+	}
+	if n := t.end - t.pos; n < len(lit) && string(t.buf[t.pos:t.end]) == lit[:n] {
+		return Token{}, t.syntaxErrAt(t.end, "unexpected end of input")
+	}
+	for j := 0; j < len(lit); j++ {
+		if t.pos+j >= t.end || t.buf[t.pos+j] != lit[j] {
+			return Token{}, t.syntaxErrAt(t.pos+j, "invalid literal")
+		}
+	}
+	i := t.pos + len(lit)
+	if err := t.checkDelim(i); err != nil {
+		return Token{}, err
+	}
+	tok := Token{Kind: kind, Raw: t.buf[t.pos:i], Offset: t.InputOffset()}
+	t.pos = i
+	return tok, nil
+}
+
+// checkDelim 确认数字/字面量后紧跟分隔符，拒绝 "truex"、"1true"
+func (t *Tokenizer) checkDelim(i int) error {
+	if i < t.end {
+		c := t.buf[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isNumberByte(c) || c == '_' {
+			return t.syntaxErrAt(i, "invalid character "+strconv.QuoteRune(rune(c)))
+		}
+	}
+	return nil
+}
+
+func isNumberByte(c byte) bool {
+	return c >= '0' && c <= '9' || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E'
+}
+
+func (t *Tokenizer) invalid(c byte) error {
+	return t.syntaxErr("invalid character " + strconv.QuoteRune(rune(c)))
+}
+
+func (t *Tokenizer) syntaxErr(msg string) error { return t.syntaxErrAt(t.pos, msg) }
+
+func (t *Tokenizer) syntaxErrAt(i int, msg string) error {
+	return &zeronode.SyntaxError{Offset: int(t.off) + i, Msg: msg}
+}
+
+// ForEachDocument 是 zeronode.ForEachDocument 的流式版本：从 r 中依次读取
+// 首尾相接或以空白分隔的 JSON 值，offset 为文档在流中的起始偏移。
+// n 指向内部缓冲，仅在回调期间有效；fn 返回 false 时停止。
+func ForEachDocument(r io.Reader, fn func(offset int64, n zeronode.Node) bool) error {
+	tz := NewTokenizer(r)
+	for {
+		raw, err := tz.ReadValue()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !fn(tz.InputOffset()-int64(len(raw)), zeronode.FromBytes(raw)) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package stream
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+func collect(t *testing.T, in string, size int) ([]string, error) {
+	t.Helper()
+	tz := NewTokenizerSize(iotest.OneByteReader(strings.NewReader(in)), size)
+	var out []string
+	for {
+		tok, err := tz.Next()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, tok.Kind.String()+":"+string(tok.Raw))
+	}
+}
+
+func TestTokenizer(t *testing.T) {
+	in := `{"a": [1, -2.5e3, "x\"éy"], "b": {"t": true, "f": false, "n": null}} 7 "s"`
+	got, err := collect(t, in, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"{:{", `key:"a"`, "[:[", "number:1", "number:-2.5e3", `string:"x\"éy"`, "]:]",
+		`key:"b"`, "{:{", `key:"t"`, "bool:true", `key:"f"`, "bool:false", `key:"n"`, "null:null", "}:}", "}:}",
+		"number:7", `string:"s"`,
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("got  %v\nwant %v", got, want)
+	}
+
+	// 超过缓冲的长字符串与偏移
+	long := strings.Repeat("z", 100)
+	tz := NewTokenizerSize(strings.NewReader(`  ["`+long+`"]`), 16)
+	tz.Next()
+	tok, err := tz.Next()
+	if err != nil || tok.Text() != long || tok.Offset != 3 {
+		t.Fatalf("long = %v %v %d", len(tok.Text()), err, tok.Offset)
+	}
+}
+
+func TestTokenizerErrors(t *testing.T) {
+	for _, in := range []string{`{"a" 1}`, `[1,]`, `{"a":truex}`, `[01]`, `["\q"]`, `{"a":1`, `[tru`, `]`} {
+		_, err := collect(t, in, 16)
+		var se *zeronode.SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("%s: want SyntaxError, got %v", in, err)
+		}
+	}
+}
+
+func TestTokenizerSkip(t *testing.T) {
+	tz := NewTokenizer(strings.NewReader(`{"skip": {"x": [1, {"y": 2}]}, "keep": 3}`))
+	tz.Next()
+	tz.Next()
+	if tok, _ := tz.Next(); tok.Kind != ObjectBegin {
+		t.Fatal("want object")
+	}
+	if err := tz.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := tz.Next(); tok.Text() != "keep" {
+		t.Fatalf("after skip: %s", tok.Raw)
+	}
+	if tok, _ := tz.Next(); tok.Kind != Number || tz.Depth() != 1 {
+		t.Fatal("want number at depth 1")
+	}
+}
+
+func TestTokenizerReadValue(t *testing.T) {
+	in := `{"meta": {"n": 2}, "data": {"items": [{"id": 1, "s": "` + strings.Repeat("a", 40) + `"}, 2, [3]]}}`
+	tz := NewTokenizerSize(iotest.OneByteReader(strings.NewReader(in)), 16)
+	if err := tz.Seek("data", "items", "0"); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := tz.ReadValue()
+	if err != nil || zeronode.FromBytes(raw).Get("id").Raw() == nil || len(raw) != 58 {
+		t.Fatalf("ReadValue = %s, %v", raw, err)
+	}
+	var rest []string
+	for tz.More() {
+		raw, _ := tz.ReadValue()
+		rest = append(rest, string(raw))
+	}
+	if strings.Join(rest, " ") != "2 [3]" {
+		t.Fatalf("rest = %v", rest)
+	}
+
+	tz = NewTokenizer(strings.NewReader(in))
+	if err := tz.Seek("data", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+	tz = NewTokenizer(strings.NewReader(`{"data": {"items": [1`))
+	if err := tz.Seek("data", "items", "3"); errors.Is(err, ErrNotFound) || err == nil {
+		t.Fatalf("want syntax error, got %v", err)
+	}
+}
+
+func TestForEachDocument(t *testing.T) {
+	in := ` {"a":1}{"a":2}
+[3] "s" 4 null`
+	var offs []int64
+	var raws []string
+	err := ForEachDocument(iotest.OneByteReader(strings.NewReader(in)), func(off int64, n zeronode.Node) bool {
+		offs = append(offs, off)
+		raws = append(raws, string(n.Raw()))
+		return true
+	})
+	if err != nil || strings.Join(raws, "|") != `{"a":1}|{"a":2}|[3]|"s"|4|null` {
+		t.Fatalf("ForEachDocument = %v, %v", raws, err)
+	}
+	for i, want := range []int64{1, 8, 16, 20, 24, 26} {
+		if offs[i] != want {
+			t.Fatalf("offsets = %v", offs)
+		}
+	}
+	if err := ForEachDocument(strings.NewReader(`{} {"a":`), func(int64, zeronode.Node) bool { return true }); err == nil {
+		t.Fatal("want truncated error")
+	}
+}
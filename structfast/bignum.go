@@ -0,0 +1,85 @@
+package structfast
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+var (
+	numberType   = reflect.TypeOf(json.Number(""))
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+)
+
+// decodeNumber 处理保留精度的数字类型：json.Number、big.Int、big.Float（及其指针，指针由调用方解开）。
+// handled 为 false 表示 fv 不是这些类型。Loose 模式下同样接受数字形式的字符串，
+// 便于解码以字符串下发的长订单号。
+func decodeNumber(n zeronode.Node, fv reflect.Value, st *decodeState) (ok, handled bool) {
+	t := fv.Type()
+	if t != numberType && t != bigIntType && t != bigFloatType {
+		return false, false
+	}
+	var num json.Number
+	switch {
+	case n.Type() == 'n':
+		num, _ = n.Number()
+	case n.Type() == 's' && st.opts.Loose:
+		s := n.UnescapedString()
+		if zeronode.Validate([]byte(s)) == nil && zeronode.FromBytes([]byte(s)).Type() == 'n' {
+			num = json.Number(s)
+		}
+	}
+	if num == "" {
+		st.mismatch(n, t)
+		return false, true
+	}
+	switch t {
+	case numberType:
+		fv.SetString(string(num))
+	case bigIntType:
+		x, ok := parseBigInt(string(num))
+		if !ok {
+			st.mismatch(n, t)
+			return false, true
+		}
+		fv.Set(reflect.ValueOf(x).Elem())
+	default:
+		// 精度随位数增长（每位十进制约 3.33 bit），短数字至少 64 bit，保证字面量原样可逆
+		prec := uint(len(num)) * 4
+		if prec < 64 {
+			prec = 64
+		}
+		x, _, err := big.ParseFloat(string(num), 10, prec, big.ToNearestEven)
+		if err != nil {
+			st.mismatch(n, t)
+			return false, true
+		}
+		fv.Set(reflect.ValueOf(x).Elem())
+	}
+	return true, true
+}
+
+// maxBigExp big.Int 接受的十进制指数绝对值上限，防止 1e999999999 这类输入放大成巨大整数
+const maxBigExp = 1000
+
+// parseBigInt 解析整数字面量；1e3、2.0 这类值为整数的写法同样接受，1.5 不接受
+func parseBigInt(s string) (*big.Int, bool) {
+	if x, ok := new(big.Int).SetString(s, 10); ok {
+		return x, true
+	}
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		if e, err := strconv.Atoi(s[i+1:]); err != nil || e > maxBigExp || e < -maxBigExp {
+			return nil, false
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || !r.IsInt() {
+		return nil, false
+	}
+	return r.Num(), true
+}
@@ -1,58 +1,223 @@
 package structfast
 
 import (
+	"bytes"
+	"encoding/base64"
+	"errors"
 	"reflect"
+	"strconv"
 	"time"
 	"unsafe"
 
+	"github.com/icloudza/gcjson/errs"
+	"github.com/icloudza/gcjson/parser"
 	"github.com/icloudza/gcjson/zeronode"
 )
 
 var timeType = reflect.TypeOf(time.Time{})
 
+var orderedMapType = reflect.TypeOf(parser.OrderedMap{})
+
+// Decode 将对象节点解码到 out，至少写入一个字段、且没有校验失败（validate 标签）
+// 与标签错误时返回 true。
+// 使用全局选项（见 SetOptions）；需要知道失败原因（如整数溢出、具体违规项）时使用 DecodeErr。
 func Decode[T any](root zeronode.Node, out *T) bool {
-	if out == nil || root.Type() != 'o' {
+	if out == nil || root.Type() != 'o' || dupErr(root) != nil {
 		return false
 	}
 	rv := reflect.ValueOf(out).Elem()
-	return decodeStruct(root, rv, getTypePlan(rv.Type()))
+	st := newState(*defaultOpts.Load())
+	return st.begin(root) && decodeStruct(root, rv, getTypePlan(rv.Type()), &st) && !st.rejected()
+}
+
+// DecodeErr 同 Decode，但返回解码过程中的全部错误（errors.Join）。
+// 出错的字段保持原值，其余字段照常写入。
+func DecodeErr[T any](root zeronode.Node, out *T) error {
+	return DecodeWith(root, out, *defaultOpts.Load())
+}
+
+// DecodeWith 同 DecodeErr，使用调用方指定的选项。
+func DecodeWith[T any](root zeronode.Node, out *T, opts Options) error {
+	if out == nil {
+		return errNilOut
+	}
+	if root.Type() != 'o' {
+		return rootErr(root)
+	}
+	if err := dupErr(root); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(out).Elem()
+	st := newState(opts)
+	if st.begin(root) {
+		decodeStruct(root, rv, getTypePlan(rv.Type()), &st)
+	}
+	return st.err()
+}
+
+// DecodeValue 将任意 JSON 节点解码到任意类型 out：标量（带范围检查）、time.Time、
+// 切片、数组、map、结构体、指针与 interface{}。根节点不要求是对象。
+// 使用全局选项；类型不符返回 *errs.ErrTypeMismatch，溢出返回 *RangeError。
+// 根节点为 null 时只能写入指针、切片、map、interface{} 与 Optional/Nullable，
+// 其他类型视为类型不符。
+func DecodeValue[T any](n zeronode.Node, out *T) error {
+	if out == nil {
+		return errNilOut
+	}
+	if n.Type() == 0 {
+		return errs.NotFound("")
+	}
+	if err := dupErr(n); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(out).Elem()
+	st := newState(*defaultOpts.Load())
+	if l := st.bud.Limits(); !st.limit(l.CheckSize(len(n.Raw()))) {
+		return st.err()
+	}
+	if n.Type() == 'l' && !nullable(rv) {
+		st.mismatch(n, rv.Type())
+		return st.err()
+	}
+	if !decodeValue(n, rv, nil, &st) && len(st.errs) == 0 {
+		st.mismatch(n, rv.Type())
+	}
+	return st.err()
+}
+
+// nullable fv 能否承载 null
+func nullable(fv reflect.Value) bool {
+	switch fv.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	case reflect.Struct:
+		_, _, ok := optionalElem(fv.Type())
+		return ok
+	}
+	return false
 }
 
 // ===== 核心递归 =====
 
-func decodeStruct(obj zeronode.Node, dst reflect.Value, plan *typePlan) bool {
+func decodeStruct(obj zeronode.Node, dst reflect.Value, plan *typePlan, st *decodeState) bool {
+	if !st.plan(plan) {
+		return false
+	}
 	okAny := false
-	for _, f := range plan.fields {
+	for i := range plan.fields {
+		f := &plan.fields[i]
 		n := getByPath(obj, f.path)
 		if n.Type() == 0 {
+			// 缺失字段填充默认值；合并解码（DecodeInto）保持原值且不校验
+			if st.present == nil {
+				fv := dst.Field(f.index)
+				applyFieldDefault(fv, f, false, st)
+				if f.valid != nil && f.valid.required && fv.IsZero() {
+					for _, k := range f.path {
+						st.pushKey(k)
+					}
+					st.violation("required", nil)
+					st.pop(len(f.path))
+				}
+			}
 			continue
 		}
-		if decodeValue(n, dst.Field(f.index)) {
+		for _, k := range f.path {
+			st.pushKey(k)
+		}
+		if st.present != nil {
+			st.present[st.pathString()] = struct{}{}
+		}
+		fv := dst.Field(f.index)
+		if decodeValue(n, fv, f.time, st) {
 			okAny = true
 		}
+		if f.valid != nil {
+			f.valid.check(fv, st)
+		}
+		st.pop(len(f.path))
 	}
 	return okAny
 }
 
-func decodeValue(n zeronode.Node, fv reflect.Value) bool {
-	if !fv.CanSet() {
+// to 为字段级时间选项（可为 nil），沿指针/切片/map 元素向下传递
+func decodeValue(n zeronode.Node, fv reflect.Value, to *timeOpts, st *decodeState) bool {
+	if !fv.CanSet() || !st.within(n) {
 		return false
 	}
 
+	// Optional / Nullable 自行处理 null 与缺失
+	if fv.Kind() == reflect.Struct && fv.Type() != timeType {
+		if d, ok := fv.Addr().Interface().(optionalDecoder); ok {
+			return d.decodeNode(n, to, st)
+		}
+	}
+
+	// null：与 encoding/json 一致，引用类型置 nil，其他类型保持不变；
+	// KeepOnNull 时一律保持原值（指针也不分配）
+	if n.Type() == 'l' {
+		if !st.opts.KeepOnNull {
+			switch fv.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+				fv.SetZero()
+			}
+		}
+		return true
+	}
+
 	// 指针：按元素递归
 	if fv.Kind() == reflect.Pointer {
 		if fv.IsNil() {
 			fv.Set(reflect.New(fv.Type().Elem()))
 		}
-		return decodeValue(n, fv.Elem())
+		return decodeValue(n, fv.Elem(), to, st)
+	}
+
+	// json.Number / big.Int / big.Float：保留全部精度
+	if ok, handled := decodeNumber(n, fv, st); handled {
+		return ok
+	}
+
+	// parser.OrderedMap：保留键顺序与重复键
+	if fv.Type() == orderedMapType {
+		if n.Type() != 'o' {
+			st.mismatch(n, fv.Type())
+			return false
+		}
+		m, ok := st.native(n, true)
+		if ok {
+			fv.Set(reflect.ValueOf(m).Elem())
+		}
+		return ok
 	}
 
 	// time.Time
 	if fv.Type() == timeType {
-		if t, ok := parseTimeNode(n); ok {
+		t, ok, overflow := parseTimeNode(n, to)
+		if ok {
 			*(*time.Time)(unsafe.Pointer(fv.UnsafeAddr())) = t
 			return true
 		}
+		if overflow {
+			st.rangeErr(n.Raw(), fv.Type())
+		} else {
+			st.mismatch(n, fv.Type())
+		}
+		return false
+	}
+
+	// time.Duration："1m30s" 或数字（默认纳秒，可用 time_unit 指定）
+	if fv.Type() == durationType {
+		d, ok, overflow := parseDurationNode(n, to)
+		if ok {
+			fv.SetInt(int64(d))
+			return true
+		}
+		if overflow {
+			st.rangeErr(n.Raw(), fv.Type())
+		} else {
+			st.mismatch(n, fv.Type())
+		}
 		return false
 	}
 
@@ -72,78 +237,195 @@ func decodeValue(n zeronode.Node, fv reflect.Value) bool {
 		}
 	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
 		if n.Type() == 'n' {
-			if v, ok := n.Int(); ok {
+			if v, ok := n.Int(); ok && !fv.OverflowInt(v) {
 				fv.SetInt(v)
 				return true
 			}
+			if isIntegerRaw(n.Raw()) {
+				st.rangeErr(n.Raw(), fv.Type())
+				return false
+			}
 		}
-	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8, reflect.Uintptr:
 		if n.Type() == 'n' {
-			if v, ok := n.Int(); ok && v >= 0 {
-				fv.SetUint(uint64(v))
+			if v, ok := n.Uint64(); ok && !fv.OverflowUint(v) {
+				fv.SetUint(v)
 				return true
 			}
+			if isIntegerRaw(n.Raw()) {
+				st.rangeErr(n.Raw(), fv.Type())
+				return false
+			}
 		}
 	case reflect.Float64, reflect.Float32:
 		if n.Type() == 'n' {
 			if v, ok := n.Float(); ok {
+				if fv.OverflowFloat(v) {
+					st.rangeErr(n.Raw(), fv.Type())
+					return false
+				}
 				fv.SetFloat(v)
 				return true
 			}
 		}
 	case reflect.Struct:
 		if n.Type() == 'o' {
-			return decodeStruct(n, fv, getTypePlan(fv.Type()))
+			// 与 encoding/json 一致：任何对象都能写入结构体，即使没有匹配的字段
+			plan := getTypePlan(fv.Type())
+			decodeStruct(n, fv, plan, st)
+			return plan.check(st.inDefault) == nil
 		}
+	case reflect.Interface:
+		// 仅支持 interface{}：按 parser.ToNativeBytes 的规则取原生值，UseNumber 时数字为 json.Number
+		if fv.NumMethod() != 0 {
+			return false
+		}
+		x, ok := st.native(n, false)
+		if !ok {
+			return false
+		}
+		if x != nil {
+			fv.Set(reflect.ValueOf(x))
+		} else {
+			fv.SetZero()
+		}
+		return true
+	case reflect.Array:
+		if n.Type() != 'a' {
+			break
+		}
+		fv.SetZero()
+		n.ForEachArray(func(i int, elem zeronode.Node) bool {
+			if i >= fv.Len() || !st.members('a', i+1, elem) {
+				return false
+			}
+			st.pushIdx(i)
+			decodeValue(elem, fv.Index(i), to, st)
+			st.pop(1)
+			return !st.limited
+		})
+		return true
 	case reflect.Slice:
-		// []byte: 从字符串
-		if fv.Type().Elem().Kind() == reflect.Uint8 {
-			if n.Type() == 's' {
+		// []byte: 从字符串，默认按标准 base64 解码（同 encoding/json）；数组形式走通用路径
+		if fv.Type().Elem().Kind() == reflect.Uint8 && n.Type() == 's' {
+			s := n.UnescapedString()
+			if st.opts.RawBytes {
 				// 复制一份，避免引用底层 JSON 缓冲
-				sb := []byte(n.UnescapedString())
-				fv.SetBytes(sb)
+				fv.SetBytes([]byte(s))
 				return true
 			}
-			return false
+			b := make([]byte, base64.StdEncoding.DecodedLen(len(s)))
+			m, err := base64.StdEncoding.Decode(b, []byte(s))
+			if err != nil {
+				st.fieldErr(err)
+				return false
+			}
+			fv.SetBytes(b[:m])
+			return true
 		}
 		if n.Type() != 'a' {
+			st.mismatch(n, fv.Type())
 			return false
 		}
 		et := fv.Type().Elem()
 		tmp := reflect.MakeSlice(fv.Type(), 0, 8)
-		n.ForEachArray(func(_ int, elem zeronode.Node) bool {
+		n.ForEachArray(func(i int, elem zeronode.Node) bool {
+			if !st.members('a', i+1, elem) {
+				return false
+			}
 			ev := reflect.New(et).Elem()
-			if decodeValue(elem, ev) {
+			st.pushIdx(i)
+			if decodeValue(elem, ev, to, st) {
 				tmp = reflect.Append(tmp, ev)
 			}
-			return true
+			st.pop(1)
+			return !st.limited
 		})
 		fv.Set(tmp)
 		return true
 	case reflect.Map:
 		// 仅支持 map[string]T
-		if fv.Type().Key().Kind() != reflect.String || n.Type() != 'o' {
+		if fv.Type().Key().Kind() != reflect.String {
+			return false
+		}
+		if n.Type() != 'o' {
+			st.mismatch(n, fv.Type())
 			return false
 		}
 		vt := fv.Type().Elem()
 		if fv.IsNil() {
 			fv.Set(reflect.MakeMapWithSize(fv.Type(), 8))
 		}
+		// 重复键：默认第一个生效，DupLast 时后者覆盖；合并到已有 map 时另记本对象出现过的键
+		keepFirst := zeronode.GetDupPolicy() != zeronode.DupLast
+		var seen map[string]struct{}
+		if keepFirst && fv.Len() > 0 {
+			seen = make(map[string]struct{}, 8)
+		}
+		cnt := 0
 		n.ForEachObject(func(k []byte, vv zeronode.Node) bool {
+			if cnt++; !st.members('o', cnt, vv) || !st.limit(st.bud.Key(len(k), vv.Offset())) {
+				return false
+			}
+			ks := string(k)
+			if keepFirst {
+				if seen != nil {
+					if _, dup := seen[ks]; dup {
+						return true
+					}
+					seen[ks] = struct{}{}
+				} else if fv.MapIndex(reflect.ValueOf(ks)).IsValid() {
+					return true
+				}
+			}
 			ev := reflect.New(vt).Elem()
-			if decodeValue(vv, ev) {
-				fv.SetMapIndex(reflect.ValueOf(string(k)), ev)
+			st.pushKey(ks)
+			if decodeValue(vv, ev, to, st) {
+				fv.SetMapIndex(reflect.ValueOf(ks), ev)
 			}
-			return true
+			st.pop(1)
+			return !st.limited
 		})
 		return true
 	default:
 		return false
 	}
 
+	if st.opts.Loose && n.Type() != 'o' && n.Type() != 'a' && n.Type() != 'l' {
+		v, err := parser.CoerceValue(nodeScalar(n), fv.Type())
+		if err == nil {
+			fv.Set(v)
+			return true
+		}
+		if errors.Is(err, errs.ErrOutOfRange) {
+			st.rangeErr(n.Raw(), fv.Type())
+			return false
+		}
+	}
+	st.mismatch(n, fv.Type())
 	return false
 }
 
+// nodeScalar 标量节点转为 Go 值；数字保留为 json.Number 以免丢失精度
+func nodeScalar(n zeronode.Node) any {
+	switch n.Type() {
+	case 's':
+		return n.UnescapedString()
+	case 'b':
+		b, _ := n.Bool()
+		return b
+	case 'n':
+		num, _ := n.Number()
+		return num
+	}
+	return nil
+}
+
+// isIntegerRaw 判断数字字面量是否为整数形式（无小数点/指数）
+func isIntegerRaw(b []byte) bool {
+	return len(b) > 0 && bytes.IndexAny(b, ".eE") < 0
+}
+
 // ===== 路径、时间辅助 =====
 
 func getByPath(n zeronode.Node, path []string) zeronode.Node {
@@ -160,40 +442,99 @@ func getByPath(n zeronode.Node, path []string) zeronode.Node {
 	return cur
 }
 
-func parseTimeNode(n zeronode.Node) (time.Time, bool) {
+// parseTimeNode 解析时间：
+//   - 字符串：有 time_format 时仅按该 layout；否则 RFC3339/RFC3339Nano，
+//     再依次尝试内置 layout 与 RegisterTimeLayouts 注册的 layout；
+//     指定了 time_unit 时纯数字字符串按时间戳处理。
+//   - 数字：按 time_unit 换算；未指定时按数量级猜测（见 guessEpochUnit）。
+//
+// 按单位换算溢出时 overflow 为 true。
+func parseTimeNode(n zeronode.Node, to *timeOpts) (t time.Time, ok, overflow bool) {
+	cfg := timeCfg.Load()
+	loc := to.location(cfg)
 	switch n.Type() {
 	case 's':
 		s := n.UnescapedString()
-		// 快路径：RFC3339 / RFC3339Nano
+		if to != nil && to.format != "" {
+			t, err := time.ParseInLocation(to.format, s, loc)
+			return t, err == nil, false
+		}
+		if to != nil && to.unit != 0 {
+			if iv, err := strconv.ParseInt(s, 10, 64); err == nil {
+				t, ok := epochTime(iv, to.unit, loc)
+				return t, ok, !ok
+			}
+		}
+		// 快路径：RFC3339 / RFC3339Nano（自带时区）
 		if t, err := time.Parse(time.RFC3339, s); err == nil {
-			return t, true
+			return t, true, false
 		}
 		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
-			return t, true
+			return t, true, false
 		}
-		// 常见 ISO8601 变体
-		layouts := []string{
-			"2006-01-02 15:04:05",
-			"2006-01-02T15:04:05",
-			"2006-01-02",
+		for _, ly := range builtinLayouts {
+			if t, err := time.ParseInLocation(ly, s, loc); err == nil {
+				return t, true, false
+			}
 		}
-		for _, ly := range layouts {
-			if t, err := time.ParseInLocation(ly, s, time.Local); err == nil {
-				return t, true
+		for _, ly := range cfg.layouts {
+			if t, err := time.ParseInLocation(ly, s, loc); err == nil {
+				return t, true, false
 			}
 		}
 	case 'n':
+		unit := time.Duration(0)
+		if to != nil {
+			unit = to.unit
+		}
 		if iv, ok := n.Int(); ok {
-			// 10 位秒，13 位毫秒
-			switch {
-			case iv > 1e14: // 微秒/纳秒，按纳秒处理
-				return time.Unix(0, iv), true
-			case iv >= 1e12: // 毫秒
-				return time.Unix(0, iv*int64(time.Millisecond)), true
-			default: // 秒
-				return time.Unix(iv, 0), true
+			if unit == 0 {
+				unit = guessEpochUnit(iv)
+			}
+			t, ok := epochTime(iv, unit, loc)
+			return t, ok, !ok
+		}
+		// 小数时间戳（如 1700000000.123），未指定单位时按秒
+		if fv, ok := n.Float(); ok {
+			if unit == 0 {
+				unit = time.Second
 			}
+			ns, ok := scaleFloat(fv, unit)
+			if !ok {
+				return time.Time{}, false, true
+			}
+			return time.Unix(0, ns).In(loc), true, false
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// parseDurationNode 解析时长：字符串走 time.ParseDuration；
+// 数字按 time_unit 换算，未指定单位时为纳秒（与 encoding/json 一致）；换算溢出时 overflow 为 true。
+func parseDurationNode(n zeronode.Node, to *timeOpts) (d time.Duration, ok, overflow bool) {
+	unit := time.Nanosecond
+	if to != nil && to.unit != 0 {
+		unit = to.unit
+	}
+	switch n.Type() {
+	case 's':
+		s := n.UnescapedString()
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, true, false
+		}
+		if iv, err := strconv.ParseInt(s, 10, 64); err == nil {
+			v, ok := scaleUnit(iv, unit)
+			return time.Duration(v), ok, !ok
+		}
+	case 'n':
+		if iv, ok := n.Int(); ok {
+			v, ok := scaleUnit(iv, unit)
+			return time.Duration(v), ok, !ok
+		}
+		if fv, ok := n.Float(); ok {
+			v, ok := scaleFloat(fv, unit)
+			return time.Duration(v), ok, !ok
 		}
 	}
-	return time.Time{}, false
+	return 0, false, false
 }
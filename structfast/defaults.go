@@ -0,0 +1,202 @@
+package structfast
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/icloudza/gcjson/errs"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// defaultValue 字段的 default 标签。raw 为标签原文，val 为解析后的字段类型值，
+// 首次使用 typePlan 时解析一次（见 typePlan.check），之后每次使用时深拷贝；
+// 解析失败的错误同样缓存，每次使用都返回。
+type defaultValue struct {
+	raw   string
+	owner string // 所属字段（Type.Field），用于报错
+	done  atomic.Bool
+	busy  bool // 正在解析，仅在持有 defMu 时读写，用于发现自引用
+	val   reflect.Value
+	err   error
+}
+
+// defMu 串行化默认值解析。解析默认值会解码到字段类型，可能再次用到其他默认值：
+// 这些嵌套调用（nested 为 true）已在持有 defMu 的调用链中，直接解析而不再加锁。
+var defMu sync.Mutex
+
+// value 返回解析后的默认值
+func (d *defaultValue) value(f *fieldPlan, nested bool) (reflect.Value, error) {
+	if d.done.Load() {
+		return d.val, d.err
+	}
+	if !nested {
+		defMu.Lock()
+		defer defMu.Unlock()
+		if d.done.Load() {
+			return d.val, d.err
+		}
+	} else if d.busy {
+		return reflect.Value{}, fmt.Errorf("%w: %s", errDefaultCycle, d.owner)
+	}
+	d.busy = true
+	v, err := parseDefault(d.raw, f.typ, f.time)
+	d.busy = false
+	if err != nil {
+		err = fmt.Errorf("structfast: invalid default for %s: %w", d.owner, err)
+	}
+	d.val, d.err = v, err
+	d.done.Store(true)
+	return v, err
+}
+
+var errDefaultCycle = errors.New("structfast: default value refers to itself")
+
+// check 返回 plan 的标签错误（validate、time_*、default）；首次调用时解析全部默认值。
+// nested 为 true 时处于默认值解析中，跳过正在解析的字段（它们是否成环由实际使用处判断）。
+func (p *typePlan) check(nested bool) error {
+	if p.tagErr != nil {
+		return p.tagErr
+	}
+	for i := range p.fields {
+		f := &p.fields[i]
+		if f.def == nil || (nested && f.def.busy) {
+			continue
+		}
+		if _, err := f.def.value(f, nested); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDefault 先按 JSON 字面量解析（数字、布尔、对象、数组、带引号字符串），
+// 失败后按普通字符串解析，因此 default:"abc" 与 default:"\"abc\"" 等价。
+func parseDefault(raw string, t reflect.Type, to *timeOpts) (reflect.Value, error) {
+	v, err := decodeDefault([]byte(raw), t, to)
+	if err == nil || errors.Is(err, errDefaultCycle) {
+		return v, err
+	}
+	q, _ := json.Marshal(raw)
+	if v, err := decodeDefault(q, t, to); err == nil {
+		return v, nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %q as %s", raw, t)
+}
+
+func decodeDefault(b []byte, t reflect.Type, to *timeOpts) (reflect.Value, error) {
+	// 需完整校验：数字快路径会接受 "30s" 这类前缀合法的输入
+	if !json.Valid(b) {
+		return reflect.Value{}, errs.ErrInvalidJSON
+	}
+	n := zeronode.FromBytes(b)
+	v := reflect.New(t).Elem()
+	st := decodeState{inDefault: true}
+	if !decodeValue(n, v, to, &st) && len(st.errs) == 0 {
+		st.mismatch(n, t)
+	}
+	return v, st.err()
+}
+
+// ApplyDefaults 为 *p 中仍为零值的字段填充 default 标签值，
+// 并递归处理嵌套结构体与非 nil 的结构体指针。
+// 标签非法（含默认值无法解析或引用自身）时返回错误且不写入任何字段。
+func ApplyDefaults[T any](p *T) error {
+	if p == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(p).Elem()
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	var st decodeState
+	plan := getTypePlan(rv.Type())
+	if err := plan.check(false); err != nil {
+		return err
+	}
+	applyDefaults(rv, plan, true, &st)
+	return st.err()
+}
+
+// applyDefaults onlyZero=false 时无条件写入（Decode 中字段缺失），
+// onlyZero=true 时仅写入零值字段（ApplyDefaults）。
+func applyDefaults(dst reflect.Value, plan *typePlan, onlyZero bool, st *decodeState) {
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		applyFieldDefault(dst.Field(f.index), f, onlyZero, st)
+	}
+}
+
+// applyFieldDefault 默认值不可用时记录到 st 并保持字段原值
+func applyFieldDefault(fv reflect.Value, f *fieldPlan, onlyZero bool, st *decodeState) {
+	if f.def != nil {
+		if !onlyZero || fv.IsZero() {
+			v, err := f.def.value(f, st.inDefault)
+			if err != nil {
+				st.fieldErr(err)
+				return
+			}
+			fv.Set(cloneValue(v))
+		}
+		return
+	}
+	switch {
+	case f.kind == reflect.Struct && f.typ != timeType:
+		applyDefaults(fv, getTypePlan(f.typ), onlyZero, st)
+	case onlyZero && f.kind == reflect.Pointer && !fv.IsNil() &&
+		f.typ.Elem().Kind() == reflect.Struct && f.typ.Elem() != timeType:
+		applyDefaults(fv.Elem(), getTypePlan(f.typ.Elem()), onlyZero, st)
+	}
+}
+
+// cloneValue 深拷贝默认值，避免多个实例共享切片/map/指针
+func cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(cloneValue(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		it := v.MapRange()
+		for it.Next() {
+			c.SetMapIndex(it.Key(), cloneValue(it.Value()))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < c.NumField(); i++ {
+			if fv := c.Field(i); fv.CanSet() {
+				fv.Set(cloneValue(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,207 @@
+package structfast
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/icloudza/gcjson/errs"
+	"github.com/icloudza/gcjson/parser"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+var errNilOut = errors.New("structfast: nil output pointer")
+
+// rootErr 根节点不是对象时返回的类型不符错误
+func rootErr(root zeronode.Node) error {
+	return &errs.ErrTypeMismatch{Want: "object", Got: errs.JSONType(root.Type())}
+}
+
+// dupErr 重复键策略为 zeronode.DupError 时检查整个输入，返回 errs.ErrDuplicateKey
+func dupErr(n zeronode.Node) error {
+	if zeronode.GetDupPolicy() != zeronode.DupError {
+		return nil
+	}
+	return zeronode.CheckDuplicates(n.Raw())
+}
+
+// RangeError 数字超出目标字段类型的表示范围（如 300 写入 int8）
+type RangeError struct {
+	Path  string       // JSON 路径，如 items.0.count
+	Value string       // 原始数字字面量
+	Type  reflect.Type // 目标类型
+}
+
+func (e *RangeError) Error() string {
+	return "structfast: " + e.Value + " overflows " + e.Type.String() + " at " + e.Path
+}
+
+// Is 使 errors.Is(err, errs.ErrOutOfRange) 成立
+func (e *RangeError) Is(target error) bool { return target == errs.ErrOutOfRange }
+
+// FieldError 某个字段解码失败（如非法 base64），Err 为底层原因
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return "structfast: " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// pathSeg 解码路径中的一段：idx < 0 为对象键，否则为数组下标
+type pathSeg struct {
+	key string
+	idx int
+}
+
+// decodeState 单次解码的上下文：记录当前 JSON 路径与累计的错误。
+// 路径仅在出错时才拼接成字符串，热路径只做切片 push/pop。
+type decodeState struct {
+	opts    Options
+	path    []pathSeg
+	errs    []error
+	present map[string]struct{} // 非 nil 时记录出现的字段路径（DecodeInto）
+	bud     zeronode.Budget
+	limited bool // 已记录超限错误
+	counted int  // 最近计入的节点偏移 +1；指针、Optional 对同一节点重入 decodeValue 时不重复计数
+
+	inDefault bool  // 正在解析 default 标签（见 defaultValue.value）
+	planErr   error // 已记录的标签错误，同一错误只记录一次
+}
+
+// newState 按选项初始化解码上下文；opts.Limits 为 nil 时使用全局上限（不含 MaxBytes）
+func newState(opts Options) decodeState {
+	st := decodeState{opts: opts}
+	l := zeronode.GetLimits()
+	l.MaxBytes = 0
+	if opts.Limits != nil {
+		l = *opts.Limits
+	}
+	st.bud.Reset(l)
+	return st
+}
+
+// begin 检查根节点的大小与自身计数
+func (st *decodeState) begin(root zeronode.Node) bool {
+	l := st.bud.Limits()
+	return st.limit(l.CheckSize(len(root.Raw()))) && st.within(root)
+}
+
+// within 按上限计入值 n，超限时返回 false
+func (st *decodeState) within(n zeronode.Node) bool {
+	if n.Offset()+1 == st.counted {
+		return !st.limited
+	}
+	st.counted = n.Offset() + 1
+	depth, strLen := len(st.path), -1
+	switch n.Type() {
+	case 'o', 'a':
+		depth++
+	case 's':
+		strLen = len(n.Raw()) - 2
+	}
+	return st.limit(st.bud.Value(depth, strLen, n.Offset()))
+}
+
+// members 容器（'o' / 'a'）读到第 i 个成员 n
+func (st *decodeState) members(kind byte, i int, n zeronode.Node) bool {
+	return st.limit(st.bud.Members(kind, i, n.Offset()))
+}
+
+// native 将 n 物化为原生值（interface{} / OrderedMap 字段），
+// 嵌套层数与节点数按已用掉的部分扣减后再交给 parser
+func (st *decodeState) native(n zeronode.Node, ordered bool) (any, bool) {
+	l := st.bud.Limits()
+	if l.MaxDepth > 0 {
+		l.MaxDepth -= len(st.path)
+	}
+	if l.MaxNodes > 0 {
+		l.MaxNodes -= st.bud.Nodes() - 1 // n 自身已计入
+	}
+	l.MaxBytes = 0
+	x, err := parser.ToNativeBytesErr(n.Raw(), parser.NativeOptions{Ordered: ordered, UseNumber: st.opts.UseNumber, Limits: &l})
+	return x, st.limit(err)
+}
+
+// plan 检查结构体的标签错误，有错误时记录（同一错误只记录一次）并返回 false
+func (st *decodeState) plan(p *typePlan) bool {
+	err := p.check(st.inDefault)
+	if err == nil {
+		return true
+	}
+	if err != st.planErr {
+		st.planErr = err
+		st.fieldErr(err)
+	}
+	return false
+}
+
+// limit 超限错误只记录一次（带当前路径），之后的值全部跳过
+func (st *decodeState) limit(err error) bool {
+	if err == nil {
+		return true
+	}
+	if !st.limited {
+		st.limited = true
+		st.fieldErr(err)
+	}
+	return false
+}
+
+func (st *decodeState) pushKey(k string) { st.path = append(st.path, pathSeg{key: k, idx: -1}) }
+func (st *decodeState) pushIdx(i int)    { st.path = append(st.path, pathSeg{idx: i}) }
+func (st *decodeState) pop(n int)        { st.path = st.path[:len(st.path)-n] }
+
+func (st *decodeState) pathString() string {
+	var b strings.Builder
+	for i, s := range st.path {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		if s.idx < 0 {
+			b.WriteString(s.key)
+		} else {
+			b.WriteString(strconv.Itoa(s.idx))
+		}
+	}
+	return b.String()
+}
+
+func (st *decodeState) rangeErr(raw []byte, t reflect.Type) {
+	st.errs = append(st.errs, &RangeError{Path: st.pathString(), Value: string(raw), Type: t})
+}
+
+// mismatch 记录 JSON 类型与字段类型不符（errs.ErrTypeMismatch）
+func (st *decodeState) mismatch(n zeronode.Node, t reflect.Type) {
+	st.errs = append(st.errs, &errs.ErrTypeMismatch{Path: st.pathString(), Want: t.String(), Got: errs.JSONType(n.Type())})
+}
+
+func (st *decodeState) fieldErr(err error) {
+	st.errs = append(st.errs, &FieldError{Path: st.pathString(), Err: err})
+}
+
+func (st *decodeState) violation(rule string, v any) {
+	st.errs = append(st.errs, &ValidationError{Path: st.pathString(), Rule: rule, Value: v})
+}
+
+// rejected 是否有校验失败或标签错误（Decode 据此返回 false）
+func (st *decodeState) rejected() bool {
+	if st.planErr != nil {
+		return true
+	}
+	for _, err := range st.errs {
+		if _, ok := err.(*ValidationError); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// err 汇总全部错误（errors.Join），无错误返回 nil
+func (st *decodeState) err() error {
+	return errors.Join(st.errs...)
+}
@@ -0,0 +1,55 @@
+package structfast
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// FieldSet 记录一次解码中 JSON 实际出现的字段路径（按 typePlan 的 JSON 名称，
+// 如 "user.name"、"items.0.sku"）。显式传 null 的字段同样视为出现。
+type FieldSet struct {
+	paths map[string]struct{}
+}
+
+// Has 判断路径是否出现在 JSON 中
+func (fs FieldSet) Has(path string) bool {
+	_, ok := fs.paths[path]
+	return ok
+}
+
+// Len 出现的字段数量
+func (fs FieldSet) Len() int { return len(fs.paths) }
+
+// Paths 返回全部出现的路径（已排序）
+func (fs FieldSet) Paths() []string {
+	out := make([]string, 0, len(fs.paths))
+	for p := range fs.paths {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// DecodeInto 将对象节点合并解码到已有值 out：仅覆盖 JSON 中出现的字段，
+// 缺失字段保持原值（嵌套结构体与非 nil 指针同样就地合并），
+// 并返回出现的字段集合，适用于 PATCH 语义。
+func DecodeInto[T any](root zeronode.Node, out *T) (FieldSet, error) {
+	if out == nil {
+		return FieldSet{}, errNilOut
+	}
+	if root.Type() != 'o' {
+		return FieldSet{}, rootErr(root)
+	}
+	if err := dupErr(root); err != nil {
+		return FieldSet{}, err
+	}
+	rv := reflect.ValueOf(out).Elem()
+	st := newState(*defaultOpts.Load())
+	st.present = make(map[string]struct{}, 16)
+	if st.begin(root) {
+		decodeStruct(root, rv, getTypePlan(rv.Type()), &st)
+	}
+	return FieldSet{paths: st.present}, st.err()
+}
@@ -0,0 +1,261 @@
+package structfast
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema 基于 typePlan 生成 T 的 JSON Schema（draft 2020-12）。
+// 命名结构体放入 $defs 并以 $ref 引用（支持递归类型），根节点同样为 $ref。
+//
+// 字段规则：
+//   - 属性名与路径同 Decode（json 标签，支持 "a.b.c" 嵌套）；
+//   - 无 omitempty、无 default 且非指针的字段，或带 validate:"required" 的字段列入 required；
+//   - time.Time -> string/date-time，[]byte -> base64 字符串，time.Duration -> string 或 integer；
+//   - enum:"a,b,c" 标签或 validate 的 oneof 生成 enum；
+//   - validate 的 min/max/len/regexp/email/uuid 映射为对应关键字，dive 作用于 items；
+//   - default 标签写入 default，description 标签写入 description。
+//
+// 返回值可直接 json.Marshal（键有序输出）。
+func JSONSchema[T any]() map[string]any {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	g := &schemaGen{defs: map[string]any{}, names: map[reflect.Type]string{}, used: map[string]bool{}}
+	root := g.typeSchema(t)
+	root["$schema"] = jsonSchemaDraft
+	if len(g.defs) > 0 {
+		root["$defs"] = g.defs
+	}
+	return root
+}
+
+type schemaGen struct {
+	defs  map[string]any
+	names map[reflect.Type]string
+	used  map[string]bool
+}
+
+func (g *schemaGen) typeSchema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if elem, nullable, ok := optionalElem(t); ok {
+		s := g.typeSchema(elem)
+		if nullable {
+			return map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+		}
+		return s
+	}
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == durationType:
+		return map[string]any{"type": []string{"string", "integer"}}
+	case t == numberType || t == bigFloatType:
+		return map[string]any{"type": "number"}
+	case t == bigIntType:
+		return map[string]any{"type": "integer"}
+	case t == orderedMapType:
+		return map[string]any{"type": "object"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Array:
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem()), "minItems": t.Len(), "maxItems": t.Len()}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return map[string]any{"type": "object"}
+		}
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + g.define(t)}
+	default:
+		// interface{} 等：任意值
+		return map[string]any{}
+	}
+}
+
+// define 为命名结构体分配 $defs 名称并生成定义；先占位以支持递归
+func (g *schemaGen) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := defName(t.Name())
+	if g.used[name] {
+		name = defName(strings.ReplaceAll(t.String(), ".", "_"))
+	}
+	for base, i := name, 2; g.used[name]; i++ {
+		name = base + "_" + strconv.Itoa(i)
+	}
+	g.names[t] = name
+	g.used[name] = true
+	g.defs[name] = map[string]any{}
+	g.defs[name] = g.structSchema(t)
+	return name
+}
+
+// defName 将类型名转为可直接用于 "#/$defs/..." 引用的键：泛型类型名中的导入路径含 "/"，
+// 在 JSON Pointer 中会被当成路径分隔符，字母、数字、'_'、'-'、'.' 以外的字符一律替换为 '_'
+func defName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+func (g *schemaGen) structSchema(t reflect.Type) map[string]any {
+	plan := getTypePlan(t)
+	root := map[string]any{"type": "object", "properties": map[string]any{}}
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		sf := t.Field(f.index)
+		fs := g.typeSchema(f.typ)
+		g.applyFieldTags(fs, f, sf.Tag)
+
+		// "a.b.c" 路径：逐层建立内联 object
+		obj := root
+		for _, seg := range f.path[:len(f.path)-1] {
+			props := obj["properties"].(map[string]any)
+			sub, ok := props[seg].(map[string]any)
+			if !ok {
+				sub = map[string]any{"type": "object", "properties": map[string]any{}}
+				props[seg] = sub
+			}
+			obj = sub
+		}
+		name := f.path[len(f.path)-1]
+		obj["properties"].(map[string]any)[name] = fs
+		_, _, optional := optionalElem(f.typ)
+		required := (f.valid != nil && f.valid.required) ||
+			(!f.omitEmpty && f.def == nil && f.kind != reflect.Pointer && !optional)
+		if required {
+			req, _ := obj["required"].([]string)
+			obj["required"] = append(req, name)
+		}
+	}
+	return root
+}
+
+func (g *schemaGen) applyFieldTags(fs map[string]any, f *fieldPlan, tag reflect.StructTag) {
+	if d := tag.Get("description"); d != "" {
+		fs["description"] = d
+	}
+	if f.time != nil && f.typ == timeType {
+		// 自定义格式或时间戳不再是 RFC3339
+		if f.time.unit != 0 {
+			fs["type"] = "integer"
+			delete(fs, "format")
+		} else if f.time.format != "" {
+			delete(fs, "format")
+		}
+	}
+	if e := tag.Get("enum"); e != "" {
+		fs["enum"] = enumValues(strings.Split(e, ","), f.typ)
+	}
+	if f.def != nil {
+		if json.Valid([]byte(f.def.raw)) {
+			fs["default"] = json.RawMessage(f.def.raw)
+		} else {
+			fs["default"] = f.def.raw
+		}
+	}
+	if f.valid != nil {
+		applyValidator(fs, f.valid, f.typ)
+	}
+}
+
+// applyValidator 将 validate 规则映射为 JSON Schema 关键字
+func applyValidator(fs map[string]any, vd *validator, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var minKey, maxKey string
+	switch t.Kind() {
+	case reflect.String:
+		minKey, maxKey = "minLength", "maxLength"
+	case reflect.Slice, reflect.Array:
+		minKey, maxKey = "minItems", "maxItems"
+	case reflect.Map:
+		minKey, maxKey = "minProperties", "maxProperties"
+	default:
+		minKey, maxKey = "minimum", "maximum"
+	}
+	for _, r := range vd.rules {
+		switch r.kind {
+		case ruleMin:
+			fs[minKey] = r.num
+		case ruleMax:
+			fs[maxKey] = r.num
+		case ruleLen:
+			if minKey != "minimum" {
+				fs[minKey], fs[maxKey] = r.num, r.num
+			}
+		case ruleOneOf:
+			fs["enum"] = enumValues(r.set, t)
+		case ruleRegexp:
+			fs["pattern"] = r.re.String()
+		case ruleEmail:
+			fs["format"] = "email"
+		case ruleUUID:
+			fs["format"] = "uuid"
+		}
+	}
+	if vd.elem == nil {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if items, ok := fs["items"].(map[string]any); ok {
+			applyValidator(items, vd.elem, t.Elem())
+		}
+	case reflect.Map:
+		if ap, ok := fs["additionalProperties"].(map[string]any); ok {
+			applyValidator(ap, vd.elem, t.Elem())
+		}
+	}
+}
+
+// enumValues 按字段类型转换枚举值：数字类型输出数字，其余输出字符串
+func enumValues(vals []string, t reflect.Type) []any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	out := make([]any, 0, len(vals))
+	for _, v := range vals {
+		v = strings.TrimSpace(v)
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				out = append(out, f)
+				continue
+			}
+		}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package structfast
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// Optional 区分“字段缺失”与“字段有值”。JSON null 视为缺失（Set 为 false）。
+// 由 Decode 系列函数填充；也实现了 json.Marshaler / json.Unmarshaler，
+// 配合 `json:",omitzero"` 序列化时缺失字段会被省略。
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// Some 返回已设置的 Optional
+func Some[T any](v T) Optional[T] { return Optional[T]{Value: v, Set: true} }
+
+// Get 返回值及是否设置
+func (o Optional[T]) Get() (T, bool) { return o.Value, o.Set }
+
+// Or 未设置时返回 def
+func (o Optional[T]) Or(def T) T {
+	if o.Set {
+		return o.Value
+	}
+	return def
+}
+
+// IsZero 未设置时为零值（供 omitzero 使用）
+func (o Optional[T]) IsZero() bool { return !o.Set }
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*o = Optional[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(b, &o.Value); err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
+
+func (Optional[T]) optionalInfo() (reflect.Type, bool) { return reflect.TypeFor[T](), false }
+func (o Optional[T]) hasValue() bool                   { return o.Set }
+
+func (o *Optional[T]) decodeNode(n zeronode.Node, to *timeOpts, st *decodeState) bool {
+	if n.Type() == 'l' {
+		*o = Optional[T]{}
+		return true
+	}
+	if !decodeValue(n, reflect.ValueOf(&o.Value).Elem(), to, st) {
+		return false
+	}
+	o.Set = true
+	return true
+}
+
+// Nullable 三态值，用于 PATCH 语义：
+//   - 字段缺失：Present == false
+//   - 显式 null：Present == true, Null == true
+//   - 有值：Present == true, Null == false
+type Nullable[T any] struct {
+	Value   T
+	Present bool
+	Null    bool
+}
+
+// NullableOf 返回有值的 Nullable
+func NullableOf[T any](v T) Nullable[T] { return Nullable[T]{Value: v, Present: true} }
+
+// NullValue 返回显式 null 的 Nullable
+func NullValue[T any]() Nullable[T] { return Nullable[T]{Present: true, Null: true} }
+
+// Get 返回值及是否有值（缺失与 null 都返回 false）
+func (o Nullable[T]) Get() (T, bool) { return o.Value, o.Present && !o.Null }
+
+// IsAbsent 字段是否缺失
+func (o Nullable[T]) IsAbsent() bool { return !o.Present }
+
+// IsNull 字段是否显式为 null
+func (o Nullable[T]) IsNull() bool { return o.Present && o.Null }
+
+// IsZero 缺失时为零值（供 omitzero 使用）
+func (o Nullable[T]) IsZero() bool { return !o.Present }
+
+func (o Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !o.Present || o.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+func (o *Nullable[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*o = Nullable[T]{Present: true, Null: true}
+		return nil
+	}
+	if err := json.Unmarshal(b, &o.Value); err != nil {
+		return err
+	}
+	o.Present, o.Null = true, false
+	return nil
+}
+
+func (Nullable[T]) optionalInfo() (reflect.Type, bool) { return reflect.TypeFor[T](), true }
+func (o Nullable[T]) hasValue() bool                   { return o.Present && !o.Null }
+
+func (o *Nullable[T]) decodeNode(n zeronode.Node, to *timeOpts, st *decodeState) bool {
+	o.Present = true
+	if n.Type() == 'l' {
+		var zero T
+		o.Value, o.Null = zero, true
+		return true
+	}
+	o.Null = false
+	return decodeValue(n, reflect.ValueOf(&o.Value).Elem(), to, st)
+}
+
+// optionalField 由 Optional / Nullable 实现：元素类型、是否允许 null、当前是否有值
+type optionalField interface {
+	optionalInfo() (reflect.Type, bool)
+	hasValue() bool
+}
+
+type optionalDecoder interface {
+	decodeNode(n zeronode.Node, to *timeOpts, st *decodeState) bool
+}
+
+var optionalFieldType = reflect.TypeFor[optionalField]()
+
+// optionalElem 若 t 为 Optional / Nullable，返回其元素类型
+func optionalElem(t reflect.Type) (elem reflect.Type, nullable, ok bool) {
+	if t.Kind() != reflect.Struct || !t.Implements(optionalFieldType) {
+		return nil, false, false
+	}
+	elem, nullable = reflect.Zero(t).Interface().(optionalField).optionalInfo()
+	return elem, nullable, true
+}
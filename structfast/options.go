@@ -0,0 +1,38 @@
+package structfast
+
+import (
+	"sync/atomic"
+
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// Options 解码行为选项。零值即默认行为，与 encoding/json 保持一致：
+//   - []byte 字段从 base64 字符串解码；
+//   - JSON null 将指针、切片、map、interface 字段置为 nil，其他类型保持不变。
+type Options struct {
+	// RawBytes 为 true 时 []byte 字段直接取字符串内容，不做 base64 解码
+	RawBytes bool
+	// KeepOnNull 为 true 时忽略 JSON null，字段保持原值
+	KeepOnNull bool
+	// Loose 为 true 时标量字段在字符串、数字、布尔之间宽松转换（如 "30" → int），
+	// 规则与根包 AnyAsLoose 相同，见 parser.CoerceValue；溢出仍报 RangeError
+	Loose bool
+	// UseNumber 为 true 时 interface{} 字段中的数字解码为 json.Number 而非 int64/float64，
+	// 同 encoding/json 的 Decoder.UseNumber
+	UseNumber bool
+	// Limits 解码使用的输入上限（嵌套层数、元素数、字符串长度等），
+	// nil 时使用全局 zeronode.GetLimits()（MaxBytes 除外，只在查询入口检查）；超限记录为 *FieldError，可用 errors.Is(err, errs.ErrLimitExceeded) 判断
+	Limits *zeronode.Limits
+}
+
+var defaultOpts atomic.Pointer[Options]
+
+func init() {
+	defaultOpts.Store(&Options{})
+}
+
+// SetOptions 设置 Decode / DecodeErr 使用的全局默认选项。
+func SetOptions(o Options) { defaultOpts.Store(&o) }
+
+// GetOptions 返回当前全局默认选项。
+func GetOptions() Options { return *defaultOpts.Load() }
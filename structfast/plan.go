@@ -1,6 +1,7 @@
 package structfast
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 	"sync"
@@ -11,10 +12,17 @@ type fieldPlan struct {
 	index int      // struct 字段索引
 	path  []string // JSON 路径（支持 a.b.c）
 	kind  reflect.Kind
+	typ   reflect.Type
+	time  *timeOpts     // time_format / time_unit / time_loc 标签
+	def   *defaultValue // default 标签；nil 表示无默认值
+	valid *validator    // validate 标签；nil 表示不校验
+
+	omitEmpty bool // json 标签带 omitempty
 }
 
 type typePlan struct {
 	fields []fieldPlan
+	tagErr error // 标签编译错误：随 plan 一起缓存，每次解码都返回（见 typePlan.check）
 }
 
 var (
@@ -22,13 +30,18 @@ var (
 	mu       sync.Mutex
 )
 
-func Register[T any]() {
+// Register 预先构建 T 的解码计划并解析 default 标签，返回标签错误（validate、time_*、default），
+// 便于在启动时发现；不调用时首次解码同样会构建并在每次解码时返回同一错误。
+func Register[T any]() error {
 	var zero T
 	t := reflect.TypeOf(&zero)
 	if t.Kind() == reflect.Pointer {
 		t = t.Elem()
 	}
-	_ = getTypePlan(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return getTypePlan(t).check(false)
 }
 
 func getTypePlan(t reflect.Type) *typePlan {
@@ -66,6 +79,7 @@ func getTypePlan(t reflect.Type) *typePlan {
 func buildTypePlan(t reflect.Type) *typePlan {
 	n := t.NumField()
 	fp := make([]fieldPlan, 0, n)
+	var tagErr error
 	for i := 0; i < n; i++ {
 		f := t.Field(i)
 		if f.PkgPath != "" { // 非导出
@@ -76,20 +90,44 @@ func buildTypePlan(t reflect.Type) *typePlan {
 			continue
 		}
 		name := tag
+		omitEmpty := false
 		if name == "" {
 			name = f.Name
 		} else if c := strings.IndexByte(name, ','); c >= 0 {
+			omitEmpty = strings.Contains(name[c:], ",omitempty")
 			name = name[:c]
 		}
 		if name == "" {
 			name = f.Name
 		}
 		path := strings.Split(name, ".") // 支持“a.b.c”
+		var def *defaultValue
+		if raw, ok := f.Tag.Lookup("default"); ok {
+			def = &defaultValue{raw: raw, owner: t.String() + "." + f.Name}
+		}
+		var valid *validator
+		if vt := f.Tag.Get("validate"); vt != "" {
+			v, err := compileValidator(vt)
+			if err != nil && tagErr == nil {
+				tagErr = fmt.Errorf("structfast: invalid validate tag for %s.%s: %v", t, f.Name, err)
+			}
+			valid = v
+		}
+		to, err := parseTimeOpts(f.Tag)
+		if err != nil && tagErr == nil {
+			tagErr = fmt.Errorf("structfast: invalid time tag for %s.%s: %v", t, f.Name, err)
+		}
 		fp = append(fp, fieldPlan{
 			index: i,
 			path:  path,
 			kind:  f.Type.Kind(),
+			typ:   f.Type,
+			time:  to,
+			def:   def,
+			valid: valid,
+
+			omitEmpty: omitEmpty,
 		})
 	}
-	return &typePlan{fields: fp}
+	return &typePlan{fields: fp, tagErr: tagErr}
 }
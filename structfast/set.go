@@ -0,0 +1,289 @@
+package structfast
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+// Set 按路径写入 *p 的字段，路径语法同 GetByPtr（json 名 / 下标 / map key）。
+// 途经的 nil 结构体指针与 nil map 会自动分配；切片下标必须已存在。
+//
+// 类型转换规则：
+//   - 可直接赋值或可转换的同类值（如 int -> MyInt）直接写入；
+//   - 整数/浮点之间按目标位宽做范围检查，溢出返回错误；
+//   - 字符串写入数字、布尔字段时先解析（"42"、"true"）；
+//   - string / []byte / json.RawMessage 写入结构体、切片、map 等非字符串字段时按 JSON 解码；
+//   - 其他值（如 map[string]any 写入结构体、字符串写入 time.Time）先序列化为 JSON 再解码。
+func Set[T any](p *T, path string, value any) error {
+	if p == nil {
+		return errors.New("structfast: Set on nil pointer")
+	}
+	if path == "" {
+		return errors.New("structfast: empty path")
+	}
+	rt := reflect.TypeOf(p).Elem()
+	if rt.Kind() != reflect.Struct {
+		return setReflect(reflect.ValueOf(p).Elem(), path, value)
+	}
+	return Compile[T]().setAt(unsafe.Pointer(p), path, value)
+}
+
+func (s *Schema) setAt(ptr unsafe.Pointer, path string, value any) error {
+	for {
+		seg, rest := cutPath(path)
+		f, ok := s.fields[seg]
+		if !ok {
+			return fmt.Errorf("structfast: no field %q in %s", seg, s.typ)
+		}
+		addr := unsafe.Add(ptr, f.offset)
+
+		switch {
+		case f.kind == KindStruct && rest != "" && f.schema != nil:
+			s, ptr, path = f.schema, addr, rest
+			continue
+		case f.kind == KindPtr && rest != "" && f.elem != nil && f.elem.kind == KindStruct && f.elem.schema != nil:
+			pp := (*unsafe.Pointer)(addr)
+			if *pp == nil {
+				*pp = reflect.New(f.elem.schema.typ).UnsafePointer()
+			}
+			s, ptr, path = f.elem.schema, *pp, rest
+			continue
+		}
+
+		sf, ok := s.typ.FieldByName(f.name)
+		if !ok {
+			return fmt.Errorf("structfast: no field %q in %s", seg, s.typ)
+		}
+		return setReflect(reflect.NewAt(sf.Type, addr).Elem(), rest, value)
+	}
+}
+
+// setReflect 在可寻址的 v 上继续按路径写入；path 为空时写入 v 本身
+func setReflect(v reflect.Value, path string, value any) error {
+	if path == "" {
+		return assignValue(v, value)
+	}
+	seg, rest := cutPath(path)
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setReflect(v.Elem(), path, value)
+	case reflect.Struct:
+		if isTimeType(v.Type()) {
+			break
+		}
+		return lookupOrBuild(v.Type()).setAt(unsafe.Pointer(v.UnsafeAddr()), path, value)
+	case reflect.Slice, reflect.Array:
+		idx, ok := atoiSeg(seg)
+		if !ok || idx >= v.Len() {
+			return fmt.Errorf("structfast: index %q out of range (len %d)", seg, v.Len())
+		}
+		return setReflect(v.Index(idx), rest, value)
+	case reflect.Map:
+		kt := v.Type().Key()
+		if kt.Kind() != reflect.String {
+			break
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := reflect.ValueOf(seg).Convert(kt)
+		// map 值不可寻址：取出副本修改后写回
+		ev := reflect.New(v.Type().Elem()).Elem()
+		if old := v.MapIndex(key); old.IsValid() {
+			ev.Set(old)
+		}
+		if err := setReflect(ev, rest, value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, ev)
+		return nil
+	case reflect.Interface:
+		// interface 中的 map[string]any 等引用类型可原地修改
+		if !v.IsNil() && v.Elem().Kind() == reflect.Map {
+			return setReflect(v.Elem(), path, value)
+		}
+	}
+	return fmt.Errorf("structfast: cannot descend into %s at %q", v.Type(), seg)
+}
+
+// assignValue 按 Set 的转换规则把 value 写入 dst
+func assignValue(dst reflect.Value, value any) error {
+	if value == nil {
+		dst.SetZero()
+		return nil
+	}
+	src := reflect.ValueOf(value)
+	dt := dst.Type()
+	if src.Type().AssignableTo(dt) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if dt == durationType {
+			break // "1m30s" 等交给 JSON 解码
+		}
+		i, err := toInt64(src)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowInt(i) {
+			return fmt.Errorf("structfast: %d overflows %s", i, dt)
+		}
+		dst.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := toUint64(src)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowUint(u) {
+			return fmt.Errorf("structfast: %d overflows %s", u, dt)
+		}
+		dst.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(src)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowFloat(f) {
+			return fmt.Errorf("structfast: %v overflows %s", f, dt)
+		}
+		dst.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		if src.Kind() == reflect.String {
+			b, err := strconv.ParseBool(src.String())
+			if err != nil {
+				return fmt.Errorf("structfast: %q is not a bool", src.String())
+			}
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.String:
+		switch {
+		case src.Kind() == reflect.String:
+			dst.SetString(src.String())
+			return nil
+		case src.Kind() == reflect.Slice && src.Type().Elem().Kind() == reflect.Uint8:
+			dst.SetString(string(src.Bytes()))
+			return nil
+		}
+	}
+
+	if src.Type().ConvertibleTo(dt) && src.Kind() == dst.Kind() {
+		dst.Set(src.Convert(dt))
+		return nil
+	}
+
+	// JSON 文本直接解码；其他值先序列化
+	var raw []byte
+	switch x := value.(type) {
+	case json.RawMessage:
+		raw = x
+	case []byte:
+		raw = x
+	case string:
+		if json.Valid([]byte(x)) && dst.Kind() != reflect.String && dt != timeType && dt != durationType {
+			raw = []byte(x)
+		}
+	}
+	if raw == nil {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("structfast: cannot assign %T to %s: %v", value, dt, err)
+		}
+		raw = b
+	}
+	st := newState(*defaultOpts.Load())
+	tmp := reflect.New(dt).Elem()
+	tmp.Set(dst)
+	if !decodeValue(zeronode.FromBytes(raw), tmp, nil, &st) && len(st.errs) == 0 {
+		return fmt.Errorf("structfast: cannot assign %T to %s", value, dt)
+	}
+	if err := st.err(); err != nil {
+		return err
+	}
+	dst.Set(tmp)
+	return nil
+}
+
+func toInt64(v reflect.Value) (int64, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("structfast: %d overflows int64", v.Uint())
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("structfast: %v is not an integer", f)
+		}
+		return int64(f), nil
+	case reflect.String:
+		i, err := strconv.ParseInt(v.String(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("structfast: %q is not an integer", v.String())
+		}
+		return i, nil
+	}
+	return 0, fmt.Errorf("structfast: cannot convert %s to integer", v.Type())
+}
+
+func toUint64(v reflect.Value) (uint64, error) {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return 0, fmt.Errorf("structfast: %d is negative", v.Int())
+		}
+		return uint64(v.Int()), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, fmt.Errorf("structfast: %v is not an unsigned integer", f)
+		}
+		return uint64(f), nil
+	case reflect.String:
+		u, err := strconv.ParseUint(v.String(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("structfast: %q is not an unsigned integer", v.String())
+		}
+		return u, nil
+	}
+	return 0, fmt.Errorf("structfast: cannot convert %s to unsigned integer", v.Type())
+}
+
+func toFloat64(v reflect.Value) (float64, error) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.String:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		if err != nil {
+			return 0, fmt.Errorf("structfast: %q is not a number", v.String())
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("structfast: cannot convert %s to number", v.Type())
+}
@@ -2,6 +2,7 @@ package structfast
 
 import (
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 	"unsafe"
@@ -143,6 +144,9 @@ func (s *Schema) getAt(ptr unsafe.Pointer, path string) (any, bool) {
 		// 中间段需要能“下钻”
 		switch f.kind {
 		case KindStruct:
+			if i >= len(path) {
+				return f.reader(addr), true
+			}
 			if f.schema == nil {
 				return nil, false
 			}
@@ -155,22 +159,32 @@ func (s *Schema) getAt(ptr unsafe.Pointer, path string) (any, bool) {
 			if p == nil {
 				return nil, false
 			}
-			// 指向 struct：可继续下钻
+			// 指向 struct：可继续下钻；已是终点则返回指针
 			if f.elem != nil && f.elem.kind == KindStruct && f.elem.schema != nil {
+				if i >= len(path) {
+					return f.reader(addr), true
+				}
 				s = f.elem.schema
 				ptr = p
 				continue
 			}
-			// 指向标量：如果后续还有路径，则失败
+			// 指向集合等：后续路径反射下钻
 			if i < len(path) {
-				return nil, false
+				if f.elem == nil || f.elem.rtype == nil {
+					return nil, false
+				}
+				return walkReflect(reflect.NewAt(f.elem.rtype, p).Elem(), path[i:])
 			}
 			// 终端：读出指针目标
 			return f.reader(unsafe.Pointer(&p)), true
 		default:
-			// 非可下钻类型，如果还有剩余路径 -> 失败
+			// 切片/数组/map/interface 还有剩余路径：反射继续下钻（下标、字符串 key）
 			if i < len(path) {
-				return nil, false
+				sf, ok := s.typ.FieldByName(f.name)
+				if !ok {
+					return nil, false
+				}
+				return walkReflect(reflect.NewAt(sf.Type, addr).Elem(), path[i:])
 			}
 			return f.reader(addr), true
 		}
@@ -182,18 +196,56 @@ func buildSchema(rt reflect.Type) *Schema {
 	if rt.Kind() != reflect.Struct {
 		return nil
 	}
-	fields := make(map[string]field, rt.NumField())
-	for i := 0; i < rt.NumField(); i++ {
-		sf := rt.Field(i)
-		if sf.PkgPath != "" {
-			continue // 非导出字段
-		}
-		f := makeField(sf)
-		fields[sf.Name] = f
+	names := jsonFields(rt)
+	fields := make(map[string]field, len(names))
+	for name, jf := range names {
+		fields[name] = makeField(rt.Field(jf.index))
 	}
 	return &Schema{typ: rt, fields: fields}
 }
 
+// jsonField 结构体字段在 JSON 中的名称信息
+type jsonField struct {
+	index  int
+	tagged bool   // 名称来自 json 标签
+	opts   string // 标签中名称之后的选项，如 "omitempty"
+}
+
+// jsonFields 按 encoding/json 的可见性返回 JSON 名 -> 字段：有 json 名的字段只能用该名访问，
+// 非导出字段与 json:"-" 不可见；同名时带标签的字段胜出，双方都带或都不带标签则都不可见。
+// Schema 与 Walk 共用，保证 GetByPtr、Set、AnyAs 对同一路径解析到同一字段
+func jsonFields(t reflect.Type) map[string]jsonField {
+	out := make(map[string]jsonField, t.NumField())
+	hidden := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if sf.PkgPath != "" || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		jf := jsonField{index: i, tagged: name != "", opts: opts}
+		if name == "" {
+			name = sf.Name
+		}
+		if hidden[name] {
+			continue
+		}
+		if prev, dup := out[name]; dup {
+			switch {
+			case prev.tagged == jf.tagged:
+				delete(out, name)
+				hidden[name] = true
+			case jf.tagged:
+				out[name] = jf
+			}
+			continue
+		}
+		out[name] = jf
+	}
+	return out
+}
+
 func makeField(sf reflect.StructField) field {
 	t := sf.Type
 	off := sf.Offset
@@ -0,0 +1,709 @@
+package structfast_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/icloudza/gcjson/errs"
+	"github.com/icloudza/gcjson/parser"
+	"github.com/icloudza/gcjson/structfast"
+	"github.com/icloudza/gcjson/zeronode"
+)
+
+type timeModel struct {
+	Created  time.Time     `json:"created"`
+	Day      time.Time     `json:"day" time_format:"2006/01/02" time_loc:"Asia/Shanghai"`
+	Millis   time.Time     `json:"millis" time_unit:"ms"`
+	Guessed  time.Time     `json:"guessed"`
+	Timeout  time.Duration `json:"timeout"`
+	Interval time.Duration `json:"interval" time_unit:"s"`
+}
+
+func TestDecodeTime(t *testing.T) {
+	doc := []byte(`{
+		"created": "2025-08-10T14:00:00Z",
+		"day": "2025/08/10",
+		"millis": 1500,
+		"guessed": 1700000000123,
+		"timeout": "1m30s",
+		"interval": 5
+	}`)
+	var m timeModel
+	if !structfast.Decode(zeronode.FromBytes(doc), &m) {
+		t.Fatal("decode failed")
+	}
+	if !m.Created.Equal(time.Date(2025, 8, 10, 14, 0, 0, 0, time.UTC)) {
+		t.Fatalf("created = %v", m.Created)
+	}
+	sh, _ := time.LoadLocation("Asia/Shanghai")
+	if !m.Day.Equal(time.Date(2025, 8, 10, 0, 0, 0, 0, sh)) {
+		t.Fatalf("day = %v", m.Day)
+	}
+	if m.Millis.UnixMilli() != 1500 {
+		t.Fatalf("millis = %v", m.Millis.UnixMilli())
+	}
+	if m.Guessed.UnixMilli() != 1700000000123 {
+		t.Fatalf("guessed = %v", m.Guessed.UnixMilli())
+	}
+	if m.Timeout != 90*time.Second || m.Interval != 5*time.Second {
+		t.Fatalf("durations = %v, %v", m.Timeout, m.Interval)
+	}
+}
+
+func TestDecodeTimeOverflow(t *testing.T) {
+	var m struct {
+		Interval time.Duration `json:"interval" time_unit:"h"`
+		At       time.Time     `json:"at" time_unit:"h"`
+		Frac     time.Duration `json:"frac" time_unit:"s"`
+	}
+	doc := []byte(`{"interval": 9223372036854775807, "at": 9223372036854775807, "frac": 1e300}`)
+	err := structfast.DecodeErr(zeronode.FromBytes(doc), &m)
+	for _, f := range []string{"interval", "at", "frac"} {
+		if !errors.Is(err, errs.ErrOutOfRange) || !strings.Contains(err.Error(), " at "+f) {
+			t.Fatalf("%s: %v", f, err)
+		}
+	}
+	if m.Interval != 0 || !m.At.IsZero() {
+		t.Fatalf("overflowed fields written: %+v", m)
+	}
+}
+
+func TestRegisterTimeLayouts(t *testing.T) {
+	structfast.RegisterTimeLayouts("02.01.2006")
+	var m timeModel
+	structfast.Decode(zeronode.FromBytes([]byte(`{"created":"10.08.2025"}`)), &m)
+	if m.Created.Year() != 2025 || m.Created.Month() != time.August || m.Created.Day() != 10 {
+		t.Fatalf("created = %v", m.Created)
+	}
+}
+
+type rangeModel struct {
+	Small int8    `json:"small"`
+	Big   uint64  `json:"big"`
+	Items []int16 `json:"items"`
+}
+
+func TestDecodeRange(t *testing.T) {
+	doc := []byte(`{"small": 300, "big": 18446744073709551615, "items": [1, 70000]}`)
+	var m rangeModel
+	err := structfast.DecodeErr(zeronode.FromBytes(doc), &m)
+	var re *structfast.RangeError
+	if !errors.As(err, &re) {
+		t.Fatalf("expected RangeError, got %v", err)
+	}
+	if m.Small != 0 {
+		t.Fatalf("small = %d, want untouched", m.Small)
+	}
+	if m.Big != 18446744073709551615 {
+		t.Fatalf("big = %d", m.Big)
+	}
+	if !strings.Contains(err.Error(), "items.1") {
+		t.Fatalf("error path missing: %v", err)
+	}
+}
+
+type nullModel struct {
+	Data  []byte         `json:"data"`
+	Ptr   *int           `json:"ptr"`
+	Tags  []string       `json:"tags"`
+	Meta  map[string]int `json:"meta"`
+	Count int            `json:"count"`
+}
+
+func TestDecodeBase64AndNull(t *testing.T) {
+	one := 1
+	m := nullModel{Ptr: &one, Tags: []string{"x"}, Meta: map[string]int{"a": 1}, Count: 7}
+	doc := []byte(`{"data":"aGVsbG8=","ptr":null,"tags":null,"meta":null,"count":null}`)
+	if err := structfast.DecodeErr(zeronode.FromBytes(doc), &m); err != nil {
+		t.Fatal(err)
+	}
+	if string(m.Data) != "hello" {
+		t.Fatalf("data = %q", m.Data)
+	}
+	if m.Ptr != nil || m.Tags != nil || m.Meta != nil || m.Count != 7 {
+		t.Fatalf("null handling: %+v", m)
+	}
+
+	var raw nullModel
+	err := structfast.DecodeWith(zeronode.FromBytes(doc), &raw, structfast.Options{RawBytes: true})
+	if err != nil || string(raw.Data) != "aGVsbG8=" {
+		t.Fatalf("raw bytes = %q, %v", raw.Data, err)
+	}
+
+	// KeepOnNull：null 不改动字段，nil 指针也不被分配
+	keep := nullModel{Tags: []string{"x"}}
+	if err := structfast.DecodeWith(zeronode.FromBytes(doc), &keep, structfast.Options{KeepOnNull: true}); err != nil {
+		t.Fatal(err)
+	}
+	if keep.Ptr != nil || len(keep.Tags) != 1 {
+		t.Fatalf("keep on null: %+v", keep)
+	}
+}
+
+type patchUser struct {
+	Name    string `json:"name"`
+	Age     int    `json:"age"`
+	Profile struct {
+		Bio  string `json:"bio"`
+		City string `json:"city"`
+	} `json:"profile"`
+	Email *string `json:"email"`
+}
+
+func TestDecodeInto(t *testing.T) {
+	email := "a@example.com"
+	u := patchUser{Name: "Alice", Age: 30, Email: &email}
+	u.Profile.Bio = "hi"
+	u.Profile.City = "Paris"
+
+	fs, err := structfast.DecodeInto(zeronode.FromBytes([]byte(`{"age":31,"profile":{"city":"Rome"},"email":null}`)), &u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Name != "Alice" || u.Age != 31 || u.Profile.Bio != "hi" || u.Profile.City != "Rome" || u.Email != nil {
+		t.Fatalf("merged = %+v", u)
+	}
+	for _, p := range []string{"age", "profile", "profile.city", "email"} {
+		if !fs.Has(p) {
+			t.Fatalf("missing %q in %v", p, fs.Paths())
+		}
+	}
+	if fs.Has("name") || fs.Has("profile.bio") || fs.Len() != 4 {
+		t.Fatalf("unexpected paths %v", fs.Paths())
+	}
+}
+
+type defaultsModel struct {
+	Name    string            `json:"name" default:"anonymous"`
+	Port    int               `json:"port" default:"8080"`
+	Tags    []string          `json:"tags" default:"[\"a\",\"b\"]"`
+	Labels  map[string]string `json:"labels" default:"{\"env\":\"dev\"}"`
+	Timeout time.Duration     `json:"timeout" default:"30s"`
+	DB      struct {
+		Host string `json:"host" default:"localhost"`
+		Pool int    `json:"pool" default:"4"`
+	} `json:"db"`
+}
+
+func TestDecodeDefaults(t *testing.T) {
+	var m defaultsModel
+	if !structfast.Decode(zeronode.FromBytes([]byte(`{"port":9000,"db":{"pool":8}}`)), &m) {
+		t.Fatal("decode failed")
+	}
+	if m.Name != "anonymous" || m.Port != 9000 || m.Timeout != 30*time.Second {
+		t.Fatalf("scalars = %+v", m)
+	}
+	if len(m.Tags) != 2 || m.Labels["env"] != "dev" || m.DB.Host != "localhost" || m.DB.Pool != 8 {
+		t.Fatalf("composites = %+v", m)
+	}
+
+	// 默认值互不共享底层存储
+	m.Tags[0] = "changed"
+	var m2 defaultsModel
+	structfast.ApplyDefaults(&m2)
+	if m2.Tags[0] != "a" || m2.Port != 8080 || m2.DB.Pool != 4 {
+		t.Fatalf("ApplyDefaults = %+v", m2)
+	}
+}
+
+type defaultTree struct {
+	Name string        `json:"name"`
+	Kids []defaultTree `json:"kids" default:"[{\"name\":\"leaf\",\"kids\":[]}]"`
+}
+
+type defaultLoop struct {
+	Name string        `json:"name"`
+	Kids []defaultLoop `json:"kids" default:"[{\"name\":\"leaf\"}]"`
+}
+
+type defaultBad struct {
+	Port int `json:"port" default:"abc"`
+}
+
+func TestDecodeDefaultErrors(t *testing.T) {
+	var tr defaultTree
+	if err := structfast.DecodeErr(zeronode.FromBytes([]byte(`{}`)), &tr); err != nil || len(tr.Kids) != 1 || tr.Kids[0].Name != "leaf" {
+		t.Fatalf("recursive default = %+v, %v", tr, err)
+	}
+	// 自引用的默认值报错而不是死锁；错误被缓存，每次都返回
+	for i := 0; i < 2; i++ {
+		var l defaultLoop
+		if err := structfast.DecodeErr(zeronode.FromBytes([]byte(`{}`)), &l); err == nil || !strings.Contains(err.Error(), "refers to itself") {
+			t.Fatalf("#%d cyclic default: %v", i, err)
+		}
+		var b defaultBad
+		if err := structfast.DecodeErr(zeronode.FromBytes([]byte(`{"port":1}`)), &b); err == nil || !strings.Contains(err.Error(), "defaultBad.Port") {
+			t.Fatalf("#%d invalid default: %v", i, err)
+		}
+		if err := structfast.ApplyDefaults(&b); err == nil {
+			t.Fatalf("#%d ApplyDefaults: nil error", i)
+		}
+	}
+}
+
+type validateItem struct {
+	SKU string `json:"sku" validate:"required,regexp=^[A-Z]{3}-[0-9]{1,4}$"`
+	Qty int    `json:"qty" validate:"min=1,max=100"`
+}
+
+type validateOrder struct {
+	ID     string         `json:"id" validate:"omitempty,uuid"`
+	Email  string         `json:"email" validate:"required,email"`
+	Status string         `json:"status" validate:"omitempty,oneof=new|paid"`
+	Tags   []string       `json:"tags" validate:"max=2,dive,len=3"`
+	Items  []validateItem `json:"items" validate:"min=1"`
+}
+
+func TestDecodeValidate(t *testing.T) {
+	doc := []byte(`{
+		"id": "not-a-uuid",
+		"status": "lost",
+		"tags": ["abc", "toolong"],
+		"items": [{"sku": "ABC-1", "qty": 5}, {"sku": "bad", "qty": 0}]
+	}`)
+	var o validateOrder
+	err := structfast.DecodeErr(zeronode.FromBytes(doc), &o)
+	want := map[string]string{
+		"id": "uuid", "email": "required", "status": "oneof=new|paid", "tags.1": "len=3",
+		"items.1.sku": "regexp=^[A-Z]{3}-[0-9]{1,4}$", "items.1.qty": "min=1",
+	}
+	got := map[string]string{}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var ve *structfast.ValidationError
+		if errors.As(e, &ve) {
+			got[ve.Path] = ve.Rule
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %v", got)
+	}
+	for p, r := range want {
+		if got[p] != r {
+			t.Fatalf("%s: got %q want %q (all: %v)", p, got[p], r, got)
+		}
+	}
+
+	o = validateOrder{Email: "a@example.com", Items: []validateItem{{SKU: "ABC-12", Qty: 1}}}
+	if err := structfast.Validate(&o); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type schemaNode struct {
+	Name     string       `json:"name" validate:"min=1" description:"node name"`
+	Kind     string       `json:"kind,omitempty" enum:"leaf,branch"`
+	Created  time.Time    `json:"created"`
+	Children []schemaNode `json:"children,omitempty"`
+}
+
+type badValidateTag struct {
+	Name string `json:"name" validate:"min=5,bogus"`
+}
+
+type badTimeTag struct {
+	At time.Time `json:"at" time_unit:"fortnight"`
+}
+
+func TestTagErrors(t *testing.T) {
+	// 标签错误不 panic，且每次解码都返回同一错误，不会在之后静默跳过校验
+	for i := 0; i < 2; i++ {
+		var v badValidateTag
+		if err := structfast.DecodeErr(zeronode.FromBytes([]byte(`{"name":"ab"}`)), &v); err == nil || !strings.Contains(err.Error(), "invalid validate tag") {
+			t.Fatalf("#%d validate tag: %v", i, err)
+		}
+		if structfast.Decode(zeronode.FromBytes([]byte(`{"name":"ab"}`)), &v) {
+			t.Fatalf("#%d Decode accepted invalid tag", i)
+		}
+	}
+	if err := structfast.Register[badTimeTag](); err == nil || !strings.Contains(err.Error(), "time_unit") {
+		t.Fatalf("time tag: %v", err)
+	}
+	if err := structfast.Validate(&badValidateTag{}); err == nil {
+		t.Fatal("Validate: nil error")
+	}
+
+	// Decode 在校验失败时返回 false
+	var o validateOrder
+	if structfast.Decode(zeronode.FromBytes([]byte(`{"email":"bad"}`)), &o) {
+		t.Fatal("Decode ignored validation failure")
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	s := structfast.JSONSchema[schemaNode]()
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(b)
+	for _, want := range []string{
+		`"$schema":"https://json-schema.org/draft/2020-12/schema"`,
+		`"$ref":"#/$defs/schemaNode"`,
+		`"children":{"items":{"$ref":"#/$defs/schemaNode"},"type":"array"}`,
+		`"created":{"format":"date-time","type":"string"}`,
+		`"kind":{"enum":["leaf","branch"],"type":"string"}`,
+		`"name":{"description":"node name","minLength":1,"type":"string"}`,
+		`"required":["name","created"]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("schema missing %s\n%s", want, got)
+		}
+	}
+}
+
+type schemaPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+func TestJSONSchemaGenericRef(t *testing.T) {
+	b, err := json.Marshal(structfast.JSONSchema[schemaPage[pathItem]]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s struct {
+		Ref  string                     `json:"$ref"`
+		Defs map[string]json.RawMessage `json:"$defs"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatal(err)
+	}
+	name := strings.TrimPrefix(s.Ref, "#/$defs/")
+	if strings.ContainsAny(name, "/~") || s.Defs[name] == nil {
+		t.Fatalf("ref %q does not resolve: %s", s.Ref, b)
+	}
+}
+
+type pathItem struct {
+	SKU string `json:"sku"`
+}
+
+type pathDoc struct {
+	Data struct {
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+		Items []pathItem `json:"items"`
+	} `json:"data"`
+	Meta  map[string]any       `json:"meta"`
+	Index map[string]*pathItem `json:"index"`
+}
+
+func TestGetByPtrPaths(t *testing.T) {
+	var d pathDoc
+	d.Data.User.Name = "Alice"
+	d.Data.Items = []pathItem{{SKU: "A-1"}, {SKU: "B-2"}}
+	d.Meta = map[string]any{"someKey": []any{"x", map[string]any{"deep": 1}}}
+	d.Index = map[string]*pathItem{"b": {SKU: "B-2"}}
+
+	cases := map[string]any{
+		"data.user.name":      "Alice",
+		"data.items.1.sku":    "B-2",
+		"meta.someKey.0":      "x",
+		"meta.someKey.1.deep": 1,
+		"index.b.sku":         "B-2",
+	}
+	for p, want := range cases {
+		got, ok := structfast.GetByPtr(&d, p)
+		if !ok || got != want {
+			t.Fatalf("%s = %v, %v", p, got, ok)
+		}
+	}
+	for _, p := range []string{"data.items.2.sku", "meta.missing", "data.user.name.x", "Data.User.Name"} {
+		if _, ok := structfast.GetByPtr(&d, p); ok {
+			t.Fatalf("%s should not resolve", p)
+		}
+	}
+}
+
+type nameClash struct {
+	A string `json:"B"`
+	B string `json:"x"`
+	C string
+	D string `json:"C"`
+}
+
+func TestJSONNameCollision(t *testing.T) {
+	c := nameClash{A: "a", B: "b", C: "c", D: "d"}
+	// 与 encoding/json 一致：json 名优先，改名字段不再能用 Go 字段名访问
+	want := map[string]any{"B": "a", "x": "b", "C": "d"}
+	for p, w := range want {
+		if got, ok := structfast.GetByPtr(&c, p); !ok || got != w {
+			t.Fatalf("GetByPtr(%s) = %v, %v", p, got, ok)
+		}
+		if got, found, _ := structfast.Walk(c, p); !found || got != w {
+			t.Fatalf("Walk(%s) = %v, %v", p, got, found)
+		}
+	}
+	for _, p := range []string{"A", "D"} {
+		if _, ok := structfast.GetByPtr(&c, p); ok {
+			t.Fatalf("GetByPtr(%s) should not resolve", p)
+		}
+		if _, found, _ := structfast.Walk(c, p); found {
+			t.Fatalf("Walk(%s) should not resolve", p)
+		}
+	}
+	if err := structfast.Set(&c, "B", "z"); err != nil || c.A != "z" || c.B != "b" {
+		t.Fatalf("Set(B) = %v, %+v", err, c)
+	}
+}
+
+type setConfig struct {
+	Name    string         `json:"name"`
+	Level   int8           `json:"level"`
+	Ratio   float32        `json:"ratio"`
+	Enabled bool           `json:"enabled"`
+	Timeout time.Duration  `json:"timeout"`
+	DB      *setDB         `json:"db"`
+	Limits  map[string]int `json:"limits"`
+	Servers []setDB        `json:"servers"`
+}
+
+type setDB struct {
+	Host string `json:"host"`
+	Port uint16 `json:"port"`
+}
+
+func TestSet(t *testing.T) {
+	c := setConfig{Servers: []setDB{{Host: "a"}}}
+	steps := []struct {
+		path  string
+		value any
+	}{
+		{"name", "svc"},
+		{"level", 7},
+		{"ratio", "0.5"},
+		{"enabled", "true"},
+		{"timeout", "1m30s"},
+		{"db.host", "db.local"},
+		{"db.port", int64(5432)},
+		{"limits.rps", "100"},
+		{"servers.0", `{"host":"b","port":80}`},
+	}
+	for _, s := range steps {
+		if err := structfast.Set(&c, s.path, s.value); err != nil {
+			t.Fatalf("Set(%s): %v", s.path, err)
+		}
+	}
+	if c.Name != "svc" || c.Level != 7 || c.Ratio != 0.5 || !c.Enabled || c.Timeout != 90*time.Second {
+		t.Fatalf("scalars = %+v", c)
+	}
+	if c.DB == nil || c.DB.Host != "db.local" || c.DB.Port != 5432 || c.Limits["rps"] != 100 {
+		t.Fatalf("nested = %+v %+v", c.DB, c.Limits)
+	}
+	if c.Servers[0] != (setDB{Host: "b", Port: 80}) {
+		t.Fatalf("servers = %+v", c.Servers)
+	}
+
+	if err := structfast.Set(&c, "level", 300); err == nil {
+		t.Fatal("expected overflow error")
+	}
+	if err := structfast.Set(&c, "servers.3.host", "x"); err == nil {
+		t.Fatal("expected index error")
+	}
+	if err := structfast.Set(&c, "nope", 1); err == nil {
+		t.Fatal("expected unknown field error")
+	}
+}
+
+func TestDecodeTypeMismatch(t *testing.T) {
+	type doc struct {
+		Name  string   `json:"name"`
+		Count int      `json:"count"`
+		Tags  []string `json:"tags"`
+		Ok    bool     `json:"ok"`
+	}
+	var d doc
+	err := structfast.DecodeErr(zeronode.FromBytes([]byte(`{"name":1,"count":1.5,"tags":"x","ok":true}`)), &d)
+	for _, want := range []errs.ErrTypeMismatch{
+		{Path: "name", Want: "string", Got: "number"},
+		{Path: "count", Want: "int", Got: "number"},
+		{Path: "tags", Want: "[]string", Got: "string"},
+	} {
+		if !errors.Is(err, &want) {
+			t.Fatalf("missing %+v in %v", want, err)
+		}
+	}
+	if !d.Ok {
+		t.Fatal("valid fields should still decode")
+	}
+	var tm *errs.ErrTypeMismatch
+	if err := structfast.DecodeErr(zeronode.FromBytes([]byte(`[1]`)), &d); !errors.As(err, &tm) || tm.Got != "array" {
+		t.Fatalf("root: %v", err)
+	}
+}
+
+func TestDecodeOptionalNullable(t *testing.T) {
+	type patch struct {
+		Name  structfast.Nullable[string]   `json:"name"`
+		Age   structfast.Nullable[int]      `json:"age"`
+		Email structfast.Nullable[string]   `json:"email"`
+		Nick  structfast.Optional[string]   `json:"nick" validate:"min=2"`
+		Tags  structfast.Optional[[]string] `json:"tags"`
+	}
+	var p patch
+	err := structfast.DecodeErr(zeronode.FromBytes([]byte(`{"name":null,"age":30,"nick":"bo","tags":null}`)), &p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Name.IsNull() || p.Name.IsAbsent() {
+		t.Fatalf("name = %+v", p.Name)
+	}
+	if v, ok := p.Age.Get(); !ok || v != 30 {
+		t.Fatalf("age = %+v", p.Age)
+	}
+	if !p.Email.IsAbsent() || p.Email.IsNull() {
+		t.Fatalf("email = %+v", p.Email)
+	}
+	if v, ok := p.Nick.Get(); !ok || v != "bo" || p.Tags.Set {
+		t.Fatalf("nick = %+v tags = %+v", p.Nick, p.Tags)
+	}
+	if err := structfast.Validate(&patch{Nick: structfast.Some("x")}); err == nil {
+		t.Fatal("min=2 should apply to Optional value")
+	}
+
+	type out struct {
+		A structfast.Nullable[int]    `json:"a,omitzero"`
+		B structfast.Nullable[int]    `json:"b"`
+		C structfast.Optional[string] `json:"c,omitzero"`
+	}
+	b, _ := json.Marshal(out{A: structfast.NullableOf(1), B: structfast.NullValue[int]()})
+	if string(b) != `{"a":1,"b":null}` {
+		t.Fatalf("marshal = %s", b)
+	}
+	var back out
+	if err := json.Unmarshal([]byte(`{"b":null,"c":"x"}`), &back); err != nil || !back.B.IsNull() || !back.A.IsAbsent() || back.C.Or("") != "x" {
+		t.Fatalf("unmarshal = %+v, %v", back, err)
+	}
+
+	s := structfast.JSONSchema[patch]()
+	props := s["$defs"].(map[string]any)["patch"].(map[string]any)
+	if req, _ := props["required"].([]string); len(req) != 0 {
+		t.Fatalf("optional fields should not be required: %v", req)
+	}
+	if _, ok := props["properties"].(map[string]any)["name"].(map[string]any)["anyOf"]; !ok {
+		t.Fatal("nullable should allow null in schema")
+	}
+}
+
+func TestDecodeLoose(t *testing.T) {
+	type doc struct {
+		Age   int     `json:"age"`
+		Ok    bool    `json:"ok"`
+		ID    string  `json:"id"`
+		Score float64 `json:"score"`
+		Small int8    `json:"small"`
+	}
+	in := zeronode.FromBytes([]byte(`{"age":"30","ok":"yes","id":98765432109876543,"score":"1.5","small":"300"}`))
+	var d doc
+	if err := structfast.DecodeErr(in, &d); err == nil {
+		t.Fatal("strict decode should report mismatches")
+	}
+	d = doc{}
+	err := structfast.DecodeWith(in, &d, structfast.Options{Loose: true})
+	if d.Age != 30 || !d.Ok || d.ID != "98765432109876543" || d.Score != 1.5 {
+		t.Fatalf("loose decode = %+v", d)
+	}
+	var re *structfast.RangeError
+	if !errors.As(err, &re) || re.Path != "small" || !errors.Is(err, errs.ErrOutOfRange) {
+		t.Fatalf("want RangeError for small, got %v", err)
+	}
+}
+
+func TestDecodeBigNumbers(t *testing.T) {
+	type order struct {
+		ID     *big.Int    `json:"id"`
+		Amount big.Float   `json:"amount"`
+		Raw    json.Number `json:"raw"`
+		Quoted *big.Int    `json:"quoted"`
+		Extra  any         `json:"extra"`
+	}
+	data := []byte(`{"id":98765432109876543210,"amount":12345678901234567.89,"raw":1.10,
+		"quoted":"12345678901234567","extra":{"n":12345678901234567}}`)
+	var o order
+	err := structfast.DecodeErr(zeronode.FromBytes(data), &o)
+	var tm *errs.ErrTypeMismatch
+	if !errors.As(err, &tm) || tm.Path != "quoted" {
+		t.Fatalf("quoted number needs Loose, got %v", err)
+	}
+	if o.ID.String() != "98765432109876543210" || o.Amount.Text('f', 2) != "12345678901234567.89" || o.Raw != "1.10" {
+		t.Fatalf("got %v %v %v", o.ID, o.Amount.Text('f', 2), o.Raw)
+	}
+	if _, ok := o.Extra.(map[string]any)["n"].(int64); !ok {
+		t.Fatalf("extra = %#v", o.Extra)
+	}
+
+	defer structfast.SetOptions(structfast.GetOptions())
+	structfast.SetOptions(structfast.Options{Loose: true, UseNumber: true})
+	o = order{}
+	if err := structfast.DecodeErr(zeronode.FromBytes(data), &o); err != nil {
+		t.Fatal(err)
+	}
+	if o.Quoted.String() != "12345678901234567" {
+		t.Fatalf("quoted = %v", o.Quoted)
+	}
+	if n := o.Extra.(map[string]any)["n"]; n != json.Number("12345678901234567") {
+		t.Fatalf("UseNumber extra = %#v", n)
+	}
+
+	var x big.Int
+	if err := structfast.DecodeValue(zeronode.FromBytes([]byte(`1.5`)), &x); err == nil {
+		t.Fatal("1.5 into big.Int should fail")
+	}
+	if err := structfast.DecodeValue(zeronode.FromBytes([]byte(`2e3`)), &x); err != nil || x.Int64() != 2000 {
+		t.Fatalf("2e3 = %v, %v", &x, err)
+	}
+}
+
+func TestDecodeOrderedMap(t *testing.T) {
+	var out struct {
+		Headers parser.OrderedMap  `json:"headers"`
+		Body    *parser.OrderedMap `json:"body"`
+	}
+	data := []byte(`{"headers":{"X-B":"1","X-A":"2"},"body":{"b":1,"a":2}}`)
+	if err := structfast.DecodeErr(zeronode.FromBytes(data), &out); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(out.Headers.Keys(), ",") != "X-B,X-A" || strings.Join(out.Body.Keys(), ",") != "b,a" {
+		t.Fatalf("keys = %v %v", out.Headers.Keys(), out.Body.Keys())
+	}
+}
+
+func TestDecodeLimits(t *testing.T) {
+	type node struct {
+		Next *node  `json:"next"`
+		Tags []int  `json:"tags"`
+		Any  any    `json:"any"`
+		Name string `json:"name"`
+	}
+	root := zeronode.FromBytes([]byte(`{"next":{"next":{"name":"c"}},"tags":[1,2,3],"any":[[1]],"name":"abcdef"}`))
+	var v node
+	if err := structfast.DecodeWith(root, &v, structfast.Options{Limits: &zeronode.Limits{MaxDepth: 3}}); err != nil || v.Next.Next.Name != "c" {
+		t.Fatalf("within limits: %+v, %v", v, err)
+	}
+	for _, c := range []struct {
+		l    zeronode.Limits
+		path string
+	}{
+		{zeronode.Limits{MaxDepth: 2}, "next.next"},
+		{zeronode.Limits{MaxArrayLen: 2}, "tags"},
+		{zeronode.Limits{MaxStringLen: 5}, "name"},
+		{zeronode.Limits{MaxDepth: 2, MaxArrayLen: 5}, "next.next"},
+	} {
+		var v node
+		err := structfast.DecodeWith(root, &v, structfast.Options{Limits: &c.l})
+		var fe *structfast.FieldError
+		if !errors.Is(err, errs.ErrLimitExceeded) || !errors.As(err, &fe) || fe.Path != c.path {
+			t.Fatalf("%+v: %v", c.l, err)
+		}
+	}
+	// interface{} 字段按剩余层数物化
+	var w node
+	err := structfast.DecodeWith(zeronode.FromBytes([]byte(`{"any":[[[1]]]}`)), &w, structfast.Options{Limits: &zeronode.Limits{MaxDepth: 3}})
+	if !errors.Is(err, errs.ErrLimitExceeded) {
+		t.Fatalf("any: %v", err)
+	}
+}
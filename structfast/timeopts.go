@@ -0,0 +1,176 @@
+package structfast
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// timeOpts 字段级时间选项，来自结构体标签，构建 typePlan 时解析一次：
+//
+//	time_format:"2006/01/02"  字符串按该 layout 解析（优先于全局 layout）
+//	time_unit:"ms"            数字（或纯数字字符串）的单位：ns/us/ms/s/m/h
+//	time_loc:"Asia/Shanghai"  无时区信息时使用的时区
+type timeOpts struct {
+	format string
+	unit   time.Duration
+	loc    *time.Location
+}
+
+// timeConfig 全局时间解析配置（写时复制，读无锁）
+type timeConfig struct {
+	layouts []string
+	loc     *time.Location
+}
+
+var (
+	timeCfg atomic.Pointer[timeConfig]
+	timeMu  sync.Mutex
+)
+
+// 内置 layout：RFC3339 之后依次尝试
+var builtinLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+func init() {
+	timeCfg.Store(&timeConfig{loc: time.Local})
+}
+
+// RegisterTimeLayouts 追加全局时间 layout，在内置 layout 之后按注册顺序尝试。
+func RegisterTimeLayouts(layouts ...string) {
+	timeMu.Lock()
+	defer timeMu.Unlock()
+	old := timeCfg.Load()
+	cfg := &timeConfig{loc: old.loc}
+	cfg.layouts = append(append(make([]string, 0, len(old.layouts)+len(layouts)), old.layouts...), layouts...)
+	timeCfg.Store(cfg)
+}
+
+// SetTimeLocation 设置全局默认时区（默认 time.Local），nil 表示恢复 time.Local。
+func SetTimeLocation(loc *time.Location) {
+	if loc == nil {
+		loc = time.Local
+	}
+	timeMu.Lock()
+	defer timeMu.Unlock()
+	old := timeCfg.Load()
+	timeCfg.Store(&timeConfig{layouts: old.layouts, loc: loc})
+}
+
+// parseTimeOpts 从标签解析时间选项；无相关标签返回 nil。
+// 未知的 time_unit / time_loc 返回错误，不再静默回退到猜测单位或全局时区。
+func parseTimeOpts(tag reflect.StructTag) (*timeOpts, error) {
+	format, hasFormat := tag.Lookup("time_format")
+	unit, hasUnit := tag.Lookup("time_unit")
+	locName, hasLoc := tag.Lookup("time_loc")
+	if !hasFormat && !hasUnit && !hasLoc {
+		return nil, nil
+	}
+	to := &timeOpts{format: format}
+	if hasUnit {
+		if to.unit = parseTimeUnit(unit); to.unit == 0 {
+			return nil, fmt.Errorf("unknown time_unit %q", unit)
+		}
+	}
+	if hasLoc && locName != "" {
+		loc, err := time.LoadLocation(locName)
+		if err != nil {
+			return nil, fmt.Errorf("time_loc: %v", err)
+		}
+		to.loc = loc
+	}
+	return to, nil
+}
+
+func parseTimeUnit(s string) time.Duration {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "ns", "nanosecond", "nanoseconds":
+		return time.Nanosecond
+	case "us", "µs", "microsecond", "microseconds":
+		return time.Microsecond
+	case "ms", "millisecond", "milliseconds":
+		return time.Millisecond
+	case "s", "sec", "second", "seconds":
+		return time.Second
+	case "m", "min", "minute", "minutes":
+		return time.Minute
+	case "h", "hour", "hours":
+		return time.Hour
+	default:
+		return 0
+	}
+}
+
+// location 字段时区优先，其次全局时区
+func (to *timeOpts) location(cfg *timeConfig) *time.Location {
+	if to != nil && to.loc != nil {
+		return to.loc
+	}
+	return cfg.loc
+}
+
+// epochTime 将整数时间戳按单位转换为 time.Time，换算溢出时返回 false
+func epochTime(v int64, unit time.Duration, loc *time.Location) (time.Time, bool) {
+	var t time.Time
+	switch unit {
+	case time.Second:
+		t = time.Unix(v, 0)
+	case time.Millisecond:
+		t = time.UnixMilli(v)
+	case time.Microsecond:
+		t = time.UnixMicro(v)
+	case time.Nanosecond:
+		t = time.Unix(0, v)
+	default:
+		sec, ok := scaleUnit(v, unit/time.Second)
+		if !ok {
+			return time.Time{}, false
+		}
+		t = time.Unix(sec, 0)
+	}
+	return t.In(loc), true
+}
+
+// scaleUnit 计算 v*unit，溢出 int64 时返回 false
+func scaleUnit(v int64, unit time.Duration) (int64, bool) {
+	if u := int64(unit); u > 1 && (v > math.MaxInt64/u || v < math.MinInt64/u) {
+		return 0, false
+	}
+	return v * int64(unit), true
+}
+
+// scaleFloat 计算 f*unit 并取整，超出 int64（含 NaN）时返回 false
+func scaleFloat(f float64, unit time.Duration) (int64, bool) {
+	x := f * float64(unit)
+	if !(x >= math.MinInt64 && x < math.MaxInt64) {
+		return 0, false
+	}
+	return int64(x), true
+}
+
+// guessEpochUnit 未指定单位时按数量级猜测：
+// < 1e11 秒，< 1e14 毫秒，< 1e17 微秒，其余纳秒。
+func guessEpochUnit(v int64) time.Duration {
+	if v < 0 {
+		v = -v
+	}
+	switch {
+	case v < 1e11:
+		return time.Second
+	case v < 1e14:
+		return time.Millisecond
+	case v < 1e17:
+		return time.Microsecond
+	default:
+		return time.Nanosecond
+	}
+}
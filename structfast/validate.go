@@ -0,0 +1,319 @@
+package structfast
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError 字段违反 validate 标签中的某条规则
+type ValidationError struct {
+	Path  string // JSON 路径，如 items.0.qty
+	Rule  string // 违反的规则原文，如 "min=1"
+	Value any    // 字段值（指针已解引用）
+}
+
+func (e *ValidationError) Error() string {
+	return "structfast: validation failed at " + e.Path + ": " + e.Rule
+}
+
+type ruleKind uint8
+
+const (
+	ruleMin ruleKind = iota + 1
+	ruleMax
+	ruleLen
+	ruleOneOf
+	ruleRegexp
+	ruleEmail
+	ruleUUID
+)
+
+type rule struct {
+	kind ruleKind
+	text string // 规则原文，用于报错
+	num  float64
+	set  []string
+	re   *regexp.Regexp
+}
+
+// validator 由 validate 标签编译而来，构建 typePlan 时生成一次。
+// 支持的规则：
+//
+//	required          值不能为零值（缺失字段同样报错）
+//	omitempty         零值跳过其余规则
+//	min=N / max=N     数字比较数值；字符串比较字符数；切片/map 比较长度
+//	len=N             字符串字符数或切片/map 长度等于 N
+//	oneof=a|b|c       字符串或整数取值之一
+//	email / uuid      字符串格式
+//	regexp=PATTERN    字符串匹配正则；会吞掉标签剩余部分（直到 ",dive"），需放在最后
+//	dive              其后的规则作用于切片/数组/map 的每个元素，可多级嵌套
+type validator struct {
+	required  bool
+	omitEmpty bool
+	rules     []rule
+	elem      *validator // dive 之后的元素规则
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func compileValidator(tag string) (*validator, error) {
+	v := &validator{}
+	for tag != "" {
+		var tok string
+		if strings.HasPrefix(tag, "regexp=") {
+			// 正则中可能包含逗号：取到 ",dive" 或末尾
+			if i := strings.Index(tag, ",dive"); i >= 0 {
+				tok, tag = tag[:i], tag[i+1:]
+			} else {
+				tok, tag = tag, ""
+			}
+		} else if i := strings.IndexByte(tag, ','); i >= 0 {
+			tok, tag = tag[:i], tag[i+1:]
+		} else {
+			tok, tag = tag, ""
+		}
+		tok = strings.TrimSpace(tok)
+		if tok == "" {
+			continue
+		}
+		if tok == "dive" {
+			elem, err := compileValidator(tag)
+			if err != nil {
+				return nil, err
+			}
+			v.elem = elem
+			break
+		}
+		name, param, _ := strings.Cut(tok, "=")
+		r := rule{text: tok}
+		switch name {
+		case "required":
+			v.required = true
+			continue
+		case "omitempty":
+			v.omitEmpty = true
+			continue
+		case "min", "max", "len":
+			f, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s", tok)
+			}
+			r.num = f
+			switch name {
+			case "min":
+				r.kind = ruleMin
+			case "max":
+				r.kind = ruleMax
+			default:
+				r.kind = ruleLen
+			}
+		case "oneof":
+			if param == "" {
+				return nil, fmt.Errorf("empty oneof")
+			}
+			r.kind, r.set = ruleOneOf, strings.Split(param, "|")
+		case "regexp":
+			re, err := regexp.Compile(param)
+			if err != nil {
+				return nil, err
+			}
+			r.kind, r.re = ruleRegexp, re
+		case "email":
+			r.kind = ruleEmail
+		case "uuid":
+			r.kind = ruleUUID
+		default:
+			return nil, fmt.Errorf("unknown rule %q", tok)
+		}
+		v.rules = append(v.rules, r)
+	}
+	return v, nil
+}
+
+// check 校验 v，违规写入 st；路径由调用方压栈
+func (vd *validator) check(v reflect.Value, st *decodeState) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			if vd.required {
+				st.violation("required", nil)
+			}
+			return
+		}
+		v = v.Elem()
+	}
+	// Optional / Nullable：无值时同 nil 指针，有值时校验 Value
+	if _, _, ok := optionalElem(v.Type()); ok {
+		if !v.Interface().(optionalField).hasValue() {
+			if vd.required {
+				st.violation("required", nil)
+			}
+			return
+		}
+		v = v.Field(0)
+	}
+	if v.IsZero() {
+		if vd.required {
+			st.violation("required", v.Interface())
+		}
+		if vd.omitEmpty {
+			return
+		}
+	}
+	for i := range vd.rules {
+		if !vd.rules[i].ok(v) {
+			st.violation(vd.rules[i].text, v.Interface())
+		}
+	}
+	if vd.elem == nil {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			st.pushIdx(i)
+			vd.elem.check(v.Index(i), st)
+			st.pop(1)
+		}
+	case reflect.Map:
+		it := v.MapRange()
+		for it.Next() {
+			st.pushKey(fmt.Sprint(it.Key().Interface()))
+			vd.elem.check(it.Value(), st)
+			st.pop(1)
+		}
+	}
+}
+
+// ok 规则不适用于该类型时视为通过
+func (r *rule) ok(v reflect.Value) bool {
+	switch r.kind {
+	case ruleMin, ruleMax, ruleLen:
+		x, isLen, ok := measure(v)
+		if !ok || (r.kind == ruleLen && !isLen) {
+			return true
+		}
+		switch r.kind {
+		case ruleMin:
+			return x >= r.num
+		case ruleMax:
+			return x <= r.num
+		default:
+			return x == r.num
+		}
+	case ruleOneOf:
+		var s string
+		switch v.Kind() {
+		case reflect.String:
+			s = v.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s = strconv.FormatInt(v.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s = strconv.FormatUint(v.Uint(), 10)
+		default:
+			return true
+		}
+		for _, c := range r.set {
+			if c == s {
+				return true
+			}
+		}
+		return false
+	}
+	if v.Kind() != reflect.String {
+		return true
+	}
+	s := v.String()
+	switch r.kind {
+	case ruleRegexp:
+		return r.re.MatchString(s)
+	case ruleEmail:
+		a, err := mail.ParseAddress(s)
+		return err == nil && a.Address == s
+	case ruleUUID:
+		return uuidRe.MatchString(s)
+	}
+	return true
+}
+
+// measure 返回用于 min/max/len 比较的量；isLen 表示是长度而非数值
+func measure(v reflect.Value) (x float64, isLen, ok bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true, true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(v.Len()), true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false, true
+	}
+	return 0, false, false
+}
+
+// Validate 按 validate 标签校验已有值，返回全部违规（errors.Join）。
+// 递归校验嵌套结构体、结构体指针以及结构体切片/map 的元素。
+func Validate[T any](p *T) error {
+	if p == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(p).Elem()
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	var st decodeState
+	validateStruct(rv, getTypePlan(rv.Type()), &st)
+	return st.err()
+}
+
+func validateStruct(v reflect.Value, plan *typePlan, st *decodeState) {
+	if !st.plan(plan) {
+		return
+	}
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		fv := v.Field(f.index)
+		for _, k := range f.path {
+			st.pushKey(k)
+		}
+		if f.valid != nil {
+			f.valid.check(fv, st)
+		}
+		validateNested(fv, st)
+		st.pop(len(f.path))
+	}
+}
+
+func validateNested(v reflect.Value, st *decodeState) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() != timeType {
+			validateStruct(v, getTypePlan(v.Type()), st)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			st.pushIdx(i)
+			validateNested(v.Index(i), st)
+			st.pop(1)
+		}
+	case reflect.Map:
+		it := v.MapRange()
+		for it.Next() {
+			st.pushKey(fmt.Sprint(it.Key().Interface()))
+			validateNested(it.Value(), st)
+			st.pop(1)
+		}
+	}
+}
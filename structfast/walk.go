@@ -0,0 +1,273 @@
+package structfast
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// Walk 在任意 Go 值上按路径直接取值，无需序列化，结果与先序列化再查询一致：
+// map[string]any / []any 走无反射快路径，结构体按 json 名（有标签时用标签名，否则用字段名；json:"-" 的字段不可见，
+// omitempty / omitzero 且为空的字段视为缺失），切片/数组按下标，字符串 key 的 map 按 key，
+// 指针与 interface 自动解引用。
+// path 为空返回 v 本身；终点为 nil 指针/map/切片时返回 (nil, true)。
+//
+// 路径经过的值自定义了序列化（实现 json.Marshaler 或 encoding.TextMarshaler）、
+// 含匿名嵌入结构体或为非字符串 key 的 map 时 handled 为 false，调用方应序列化后再查询。
+func Walk(v any, path string) (x any, found, handled bool) {
+	if v == nil {
+		return nil, false, true
+	}
+	cur := v
+	for path != "" {
+		seg, rest := cutPath(path)
+		switch x := cur.(type) {
+		case map[string]any:
+			nv, ok := x[seg]
+			if !ok {
+				return nil, false, true
+			}
+			cur = nv
+		case []any:
+			idx, ok := atoiSeg(seg)
+			if !ok || idx >= len(x) {
+				return nil, false, true
+			}
+			cur = x[idx]
+		case nil:
+			return nil, false, true
+		default:
+			return walkJSON(reflect.ValueOf(cur), path)
+		}
+		path = rest
+	}
+	return cur, true, true
+}
+
+// walkJSON 同 walkReflect，但按 encoding/json 的字段可见性下钻（见 Walk）
+func walkJSON(v reflect.Value, path string) (any, bool, bool) {
+	for path != "" {
+		seg, rest := cutPath(path)
+		for {
+			if walkTypeOf(v.Type()).custom {
+				return nil, false, false
+			}
+			if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+				break
+			}
+			if v.IsNil() {
+				return nil, false, true
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			wt := walkTypeOf(v.Type())
+			if wt.embedded {
+				return nil, false, false
+			}
+			f, ok := wt.fields[seg]
+			if !ok {
+				return nil, false, true
+			}
+			v = v.Field(f.index)
+			if f.omitEmpty && isEmptyValue(v) || f.omitZero && isZeroValue(v) {
+				return nil, false, true
+			}
+		case reflect.Slice, reflect.Array:
+			idx, ok := atoiSeg(seg)
+			if !ok || idx >= v.Len() {
+				return nil, false, true
+			}
+			v = v.Index(idx)
+		case reflect.Map:
+			kt := v.Type().Key()
+			if kt.Kind() != reflect.String {
+				return nil, false, false
+			}
+			mv := v.MapIndex(reflect.ValueOf(seg).Convert(kt))
+			if !mv.IsValid() {
+				return nil, false, true
+			}
+			v = mv
+		default:
+			return nil, false, true
+		}
+		path = rest
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false, true
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil, true, true
+		}
+	}
+	return v.Interface(), true, true
+}
+
+// walkField 结构体字段在 JSON 中的可见信息
+type walkField struct {
+	index     int
+	omitEmpty bool
+	omitZero  bool
+}
+
+// walkType Walk 用到的类型信息，按类型缓存
+type walkType struct {
+	custom   bool                 // 自定义了序列化
+	embedded bool                 // 含匿名嵌入字段（序列化时会展开）
+	fields   map[string]walkField // 仅结构体：json 名 -> 字段，见 jsonFields
+}
+
+var walkTypes sync.Map // reflect.Type -> *walkType
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func walkTypeOf(t reflect.Type) *walkType {
+	if wt, ok := walkTypes.Load(t); ok {
+		return wt.(*walkType)
+	}
+	wt := &walkType{}
+	pt := reflect.PointerTo(t)
+	wt.custom = t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType)
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if _, hasTag := sf.Tag.Lookup("json"); sf.Anonymous && !hasTag {
+				wt.embedded = true
+			}
+		}
+		names := jsonFields(t)
+		wt.fields = make(map[string]walkField, len(names))
+		for name, jf := range names {
+			wt.fields[name] = walkField{index: jf.index, omitEmpty: hasOpt(jf.opts, "omitempty"), omitZero: hasOpt(jf.opts, "omitzero")}
+		}
+	}
+	actual, _ := walkTypes.LoadOrStore(t, wt)
+	return actual.(*walkType)
+}
+
+func hasOpt(opts, opt string) bool {
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue 同 encoding/json 的 omitempty 判定
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// isZeroValue 同 encoding/json 的 omitzero 判定：优先使用类型自身的 IsZero() bool
+func isZeroValue(v reflect.Value) bool {
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return true
+		}
+		return z.IsZero()
+	}
+	return v.IsZero()
+}
+
+func cutPath(path string) (seg, rest string) {
+	for i := 0; i < len(path); i++ {
+		if path[i] == '.' {
+			return path[:i], path[i+1:]
+		}
+	}
+	return path, ""
+}
+
+// walkReflect 在任意 Go 值上按 "a.0.b" 路径下钻：
+// 结构体走 Schema（json 名，见 jsonFields），切片/数组按下标，map 按字符串 key，
+// 指针与 interface 自动解引用。
+func walkReflect(v reflect.Value, path string) (any, bool) {
+	for path != "" {
+		seg, rest := cutPath(path)
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			if isTimeType(v.Type()) {
+				return nil, false
+			}
+			if !v.CanAddr() {
+				// map 值等不可寻址：拷贝一份再走 unsafe 快路径
+				tmp := reflect.New(v.Type()).Elem()
+				tmp.Set(v)
+				v = tmp
+			}
+			return lookupOrBuild(v.Type()).getAt(unsafe.Pointer(v.UnsafeAddr()), path)
+		case reflect.Slice, reflect.Array:
+			idx, ok := atoiSeg(seg)
+			if !ok || idx >= v.Len() {
+				return nil, false
+			}
+			v = v.Index(idx)
+		case reflect.Map:
+			kt := v.Type().Key()
+			if kt.Kind() != reflect.String {
+				return nil, false
+			}
+			mv := v.MapIndex(reflect.ValueOf(seg).Convert(kt))
+			if !mv.IsValid() {
+				return nil, false
+			}
+			v = mv
+		default:
+			return nil, false
+		}
+		path = rest
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil, true
+		}
+	}
+	return v.Interface(), true
+}
+
+func atoiSeg(s string) (int, bool) {
+	if s == "" || len(s) > 18 {
+		return 0, false
+	}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i] - '0'
+		if c > 9 {
+			return 0, false
+		}
+		n = n*10 + int(c)
+	}
+	return n, true
+}
@@ -0,0 +1,94 @@
+package gcjson
+
+import (
+	"encoding/json"
+
+	"github.com/icloudza/gcjson/convert"
+	"github.com/icloudza/gcjson/parser"
+	"github.com/icloudza/gcjson/structfast"
+	"github.com/tidwall/gjson"
+)
+
+// walkValue 对 map、切片、结构体等 Go 值输入直接按路径取值，避免整体序列化。
+// handled=false 表示应走 JSON 路径：输入是 JSON 文本、路径含 gjson 语法，
+// 或路径上的值自定义了序列化（见 structfast.Walk）。
+func walkValue(v any, path string) (x any, found, handled bool) {
+	if !isStructCandidate(v) || !isPlainPath(path) {
+		return nil, false, false
+	}
+	return structfast.Walk(v, path)
+}
+
+// lookup 取 path 对应的 gjson.Result；Go 值输入只序列化命中的子树。
+// 返回的 b 为 r 所在的 JSON 文本（r.Index 相对于 b）。
+func lookup(v any, path string) ([]byte, gjson.Result, bool) {
+	if x, found, handled := walkValue(v, path); handled {
+		if !found {
+			return nil, gjson.Result{}, false
+		}
+		b, err := convert.Marshal(x)
+		if err != nil {
+			return nil, gjson.Result{}, false
+		}
+		return b, gjson.ParseBytes(b), true
+	}
+	b, err := convert.From(v)
+	if err != nil {
+		return nil, gjson.Result{}, false
+	}
+	if b, err = dedupeInput(b); err != nil {
+		return nil, gjson.Result{}, false
+	}
+	return b, gjson.GetBytes(b, path), true
+}
+
+// subtreeResult 将已取到的 Go 值序列化为 gjson.Result
+func subtreeResult(x any) (gjson.Result, error) {
+	b, err := convert.Marshal(x)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+	return gjson.ParseBytes(b), nil
+}
+
+// toNativeValue 将 Go 值按 JSON 语义转为原生类型（map[string]any / []any / int64 ...）
+func toNativeValue(x any, o NativeOptions) any {
+	r, err := subtreeResult(x)
+	if err != nil || len(r.Raw) == 0 {
+		return nil
+	}
+	return parser.ToNativeWith(r, o)
+}
+
+// isStructCandidate 排除 JSON 文本输入，避免对其做反射检查
+func isStructCandidate(v any) bool {
+	switch v.(type) {
+	case nil, []byte, string, *string, json.RawMessage, gjson.Result, convert.Source:
+		return false
+	}
+	return true
+}
+
+// 仅允许 a.b.0.c 这种简单路径：每段非空，只含 A-Za-z0-9_-（不含 gjson 通配/查询语法）
+func isPlainPath(p string) bool {
+	if p == "" {
+		return false
+	}
+	start := 0
+	for i := 0; i <= len(p); i++ {
+		if i == len(p) || p[i] == '.' {
+			if i == start {
+				return false
+			}
+			start = i + 1
+			continue
+		}
+		ch := p[i]
+		isAlpha := (ch|0x20) >= 'a' && (ch|0x20) <= 'z'
+		isDigit := ch >= '0' && ch <= '9'
+		if !(isAlpha || isDigit || ch == '_' || ch == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+package zeronode
+
+// ForEachDocument 依次遍历 b 中首尾相接或以空白分隔的多个 JSON 值
+// （例如 `{...}{...}`、NDJSON），offset 为每个文档在 b 中的起始偏移。
+// fn 返回 false 时停止；遇到语法错误时返回 *SyntaxError，此前的文档已回调。
+func ForEachDocument(b []byte, fn func(offset int, n Node) bool) error {
+	i := skipSpaces(b, 0)
+	for i < len(b) {
+		end, err := validValue(b, i)
+		if err != nil {
+			return err
+		}
+		// 数字与字面量之间必须有分隔，"1true" 不是两个文档
+		if end < len(b) && !isDocDelim(b[end-1]) && !isDocDelim(b[end]) {
+			return &SyntaxError{Offset: end, Msg: "missing separator between documents"}
+		}
+		if !fn(i, FromBytes(b[i:end])) {
+			return nil
+		}
+		i = skipSpaces(b, end)
+	}
+	return nil
+}
+
+func isDocDelim(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '{', '}', '[', ']', '"':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package zeronode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestForEachDocument(t *testing.T) {
+	in := ` {"a":1}{"a":2}
+[3] "s" 4 null`
+	var offs []int
+	var raws []string
+	err := ForEachDocument([]byte(in), func(off int, n Node) bool {
+		offs = append(offs, off)
+		raws = append(raws, string(n.Raw()))
+		return true
+	})
+	if err != nil || strings.Join(raws, "|") != `{"a":1}|{"a":2}|[3]|"s"|4|null` {
+		t.Fatalf("ForEachDocument = %v, %v", raws, err)
+	}
+	for i, want := range []int{1, 8, 16, 20, 24, 26} {
+		if offs[i] != want {
+			t.Fatalf("offsets = %v", offs)
+		}
+	}
+	if err := ForEachDocument([]byte(`{} 1true`), func(int, Node) bool { return true }); err == nil {
+		t.Fatal("want separator error")
+	}
+}
@@ -0,0 +1,244 @@
+package zeronode
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/icloudza/gcjson/errs"
+)
+
+// DupPolicy 对象中出现重复键时的处理策略。
+// 全局生效，zeronode、raw、structfast、parser 与根包的查询和物化都遵循同一策略，
+// 避免网关与后端对同一报文取到不同的值。
+//
+// 行为变化：parser.ToNative / ToNativeBytes 及根包 Any、MapAny 等物化入口过去逐个写入 map，
+// 重复键实际是最后一个生效；现在同样遵循默认的 DupFirst，与查询结果一致。
+// 依赖旧结果的调用方可 SetDupPolicy(DupLast)。
+type DupPolicy uint8
+
+const (
+	// DupFirst 第一个生效（默认，与 gjson 的查询结果一致）
+	DupFirst DupPolicy = iota
+	// DupLast 最后一个生效（与 encoding/json 一致）
+	DupLast
+	// DupError 视为错误：返回 error 的入口报 errs.ErrDuplicateKey，
+	// Node.Get 等不返回 error 的查询对重复键返回空节点
+	DupError
+)
+
+var dupPolicy atomic.Uint32
+
+// SetDupPolicy 设置全局重复键策略
+func SetDupPolicy(p DupPolicy) { dupPolicy.Store(uint32(p)) }
+
+// GetDupPolicy 返回当前全局重复键策略
+func GetDupPolicy() DupPolicy { return DupPolicy(dupPolicy.Load()) }
+
+// Duplicate 一个重复出现的键
+type Duplicate struct {
+	Path    string // 键的完整路径（gjson 语法，特殊字符已转义），如 user.roles.0.name
+	Key     string // 反转义后的键名
+	Offsets []int  // 每次出现时键在输入中的字节偏移（指向左引号），按出现顺序
+}
+
+// FindDuplicates 列出 b 中所有重复键（按反转义后的键名比较，"a" 与 "\u0061" 视为重复），
+// 按第二次出现的位置排序，即按输入中发现重复的先后。
+// b 需为合法 JSON；非法部分之后的内容不再检查。
+func FindDuplicates(b []byte) []Duplicate {
+	var out []Duplicate
+	findDuplicates(FromBytes(b), "", func(d Duplicate) bool {
+		out = append(out, d)
+		return true
+	})
+	slices.SortStableFunc(out, func(x, y Duplicate) int { return x.Offsets[1] - y.Offsets[1] })
+	return out
+}
+
+// CheckDuplicates 存在重复键时返回可用 errors.Is(err, errs.ErrDuplicateKey) 判断的错误，
+// 报告输入中最先出现的那个重复
+func CheckDuplicates(b []byte) error {
+	var first *Duplicate
+	findDuplicates(FromBytes(b), "", func(d Duplicate) bool {
+		if first == nil || d.Offsets[1] < first.Offsets[1] {
+			first = &d
+		}
+		return true
+	})
+	if first == nil {
+		return nil
+	}
+	return errs.DuplicateKey(first.Path, first.Offsets[1])
+}
+
+// findDuplicates 深度优先遍历；fn 返回 false 时停止，返回值表示是否继续
+func findDuplicates(n Node, path string, fn func(Duplicate) bool) bool {
+	switch n.typ {
+	case 'o':
+		var seen map[string]int // 键 -> dups 中的位置（-1 表示只出现过一次）
+		var first map[string]int
+		var dups []Duplicate
+		ok := true
+		n.forEachMember(func(key []byte, koff int, v Node) bool {
+			k := string(unescapeKey(key, n.mode))
+			if seen == nil {
+				seen, first = make(map[string]int, 8), make(map[string]int, 8)
+			}
+			if di, hit := seen[k]; hit {
+				if di < 0 {
+					di = len(dups)
+					seen[k] = di
+					dups = append(dups, Duplicate{Path: joinPath(path, escapePathKey(k)), Key: k, Offsets: []int{first[k]}})
+				}
+				dups[di].Offsets = append(dups[di].Offsets, koff)
+			} else {
+				seen[k], first[k] = -1, koff
+			}
+			ok = findDuplicates(v, joinPath(path, escapePathKey(k)), fn)
+			return ok
+		})
+		if !ok {
+			return false
+		}
+		for _, d := range dups {
+			if !fn(d) {
+				return false
+			}
+		}
+	case 'a':
+		ok := true
+		n.ForEachArray(func(i int, v Node) bool {
+			ok = findDuplicates(v, joinPath(path, strconv.Itoa(i)), fn)
+			return ok
+		})
+		return ok
+	}
+	return true
+}
+
+// forEachMember 同 ForEachObject（严格模式），额外给出键左引号的偏移
+func (n Node) forEachMember(fn func(key []byte, koff int, v Node) bool) {
+	if n.typ != 'o' {
+		return
+	}
+	i := n.start + 1
+	for i < n.end {
+		i = skipSpaces(n.raw, i)
+		if i >= n.end || n.raw[i] != '"' {
+			return
+		}
+		koff := i
+		ks := i + 1
+		i++
+		for i < n.end && n.raw[i] != '"' {
+			if n.raw[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		ke := i
+		i = skipSpaces(n.raw, i+1)
+		if i >= n.end || n.raw[i] != ':' {
+			return
+		}
+		valStart, typ := skipWS(n.raw, skipSpaces(n.raw, i+1))
+		valEnd := findValueEnd(n.raw, valStart)
+		if !fn(n.raw[ks:ke], koff, Node{raw: n.raw, start: valStart, end: valEnd, typ: typ}) {
+			return
+		}
+		i = skipSpaces(n.raw, valEnd)
+		if i < n.end && n.raw[i] == ',' {
+			i++
+		}
+	}
+}
+
+// unescapeKey 键不含转义时原样返回，避免分配
+func unescapeKey(k []byte, mode Mode) []byte {
+	if bytes.IndexByte(k, '\\') < 0 {
+		return k
+	}
+	return unescapeJSONString(k, mode)
+}
+
+// keyEqual 比较原始键（可能含转义）与目标键
+func keyEqual(raw, key []byte) bool {
+	if bytes.IndexByte(raw, '\\') < 0 {
+		return bytes.Equal(raw, key)
+	}
+	return bytes.Equal(unescapeJSONString(raw, Strict), key)
+}
+
+// Dedupe 按策略 p 去掉 b 中落选的重复成员，返回等价的 JSON（重写部分不保留空白）。
+// 没有重复键时原样返回 b；p 为 DupError 且存在重复键时返回错误。
+// 供只能按“第一个生效”取值的引擎（如 gjson）在其他策略下使用。
+func Dedupe(b []byte, p DupPolicy) ([]byte, error) {
+	if p == DupError {
+		return b, CheckDuplicates(b)
+	}
+	n := FromBytes(b)
+	if !hasDuplicates(n) {
+		return b, nil
+	}
+	return appendDeduped(make([]byte, 0, len(b)), n, p == DupLast), nil
+}
+
+func hasDuplicates(n Node) bool {
+	found := false
+	findDuplicates(n, "", func(Duplicate) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+type member struct {
+	key []byte
+	v   Node
+}
+
+func appendDeduped(dst []byte, n Node, keepLast bool) []byte {
+	switch n.typ {
+	case 'o':
+		var ms []member
+		n.forEachMember(func(key []byte, _ int, v Node) bool {
+			ms = append(ms, member{key, v})
+			return true
+		})
+		win := make(map[string]int, len(ms))
+		for i, m := range ms {
+			k := string(unescapeKey(m.key, n.mode))
+			if _, hit := win[k]; !hit || keepLast {
+				win[k] = i
+			}
+		}
+		dst = append(dst, '{')
+		first := true
+		for i, m := range ms {
+			if win[string(unescapeKey(m.key, n.mode))] != i {
+				continue
+			}
+			if !first {
+				dst = append(dst, ',')
+			}
+			first = false
+			dst = append(dst, '"')
+			dst = append(dst, m.key...)
+			dst = append(dst, '"', ':')
+			dst = appendDeduped(dst, m.v, keepLast)
+		}
+		return append(dst, '}')
+	case 'a':
+		dst = append(dst, '[')
+		n.ForEachArray(func(i int, v Node) bool {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendDeduped(dst, v, keepLast)
+			return true
+		})
+		return append(dst, ']')
+	}
+	return append(dst, n.Raw()...)
+}
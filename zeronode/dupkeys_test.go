@@ -0,0 +1,50 @@
+package zeronode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/icloudza/gcjson/errs"
+)
+
+func TestDedupe(t *testing.T) {
+	defer SetDupPolicy(GetDupPolicy())
+	in := []byte(`{"a": 1, "a": [ {"b":1,"b":2} ], "c": true}`)
+	if d := FindDuplicates(in); len(d) != 2 || d[0].Key != "a" || d[0].Offsets[1] != 9 || d[1].Path != "a.0.b" {
+		t.Fatalf("FindDuplicates = %+v", d)
+	}
+	// 报告输入中最先出现的重复，而不是先报告嵌套的
+	if err := CheckDuplicates([]byte(`{"a":1,"a":2,"m":{"k":1,"k":2}}`)); err == nil || !strings.Contains(err.Error(), `"a" at offset 7`) {
+		t.Fatalf("CheckDuplicates = %v", err)
+	}
+	if err := CheckDuplicates([]byte(`{"a":1,"\u0061":2}`)); !errors.Is(err, errs.ErrDuplicateKey) {
+		t.Fatalf("escaped key not detected: %v", err)
+	}
+	if v := FromBytes(in).Get("a"); string(v.Raw()) != "1" {
+		t.Fatalf("first = %s", v.Raw())
+	}
+	SetDupPolicy(DupLast)
+	if v := FromBytes(in).Get("a"); v.Type() != 'a' {
+		t.Fatalf("last = %s", v.Raw())
+	}
+
+	for _, c := range []struct {
+		p    DupPolicy
+		want string
+	}{
+		{DupFirst, `{"a":1,"c":true}`},
+		{DupLast, `{"a":[{"b":2}],"c":true}`},
+	} {
+		out, err := Dedupe(in, c.p)
+		if err != nil || string(out) != c.want {
+			t.Fatalf("Dedupe(%d) = %s, %v", c.p, out, err)
+		}
+	}
+	if _, err := Dedupe(in, DupError); !errors.Is(err, errs.ErrDuplicateKey) {
+		t.Fatalf("DupError = %v", err)
+	}
+	if out, _ := Dedupe([]byte(`{"a": 1}`), DupLast); string(out) != `{"a": 1}` {
+		t.Fatalf("no dup should return input, got %s", out)
+	}
+}
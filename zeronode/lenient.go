@@ -0,0 +1,314 @@
+package zeronode
+
+import (
+	"strconv"
+)
+
+// Mode 输入语法模式
+type Mode uint8
+
+const (
+	Strict Mode = iota // 标准 JSON
+	JSONC              // 允许 // 与 /* */ 注释、尾逗号
+	JSON5              // JSONC 之外还允许裸键、单引号字符串、十六进制与 +1/.5/1. 数字写法
+)
+
+var modeNames = [...]string{"strict", "jsonc", "json5"}
+
+func (m Mode) String() string {
+	if int(m) < len(modeNames) {
+		return modeNames[m]
+	}
+	return "mode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// allows 报告该模式是否接受某类非标准写法
+func (m Mode) allows(k FixKind) bool {
+	switch k {
+	case FixComment, FixTrailingComma:
+		return m >= JSONC
+	case FixUnquotedKey, FixSingleQuote, FixNumber:
+		return m >= JSON5
+	}
+	return false
+}
+
+// Parse 按 mode 校验 b 并返回根节点。非 Strict 模式不改写输入：
+// 节点直接在原始字节上跳过注释与尾逗号，子节点继承同一模式。
+func Parse(b []byte, mode Mode) (Node, error) {
+	if mode == Strict {
+		if err := Validate(b); err != nil {
+			return Node{}, err
+		}
+		return FromBytes(b), nil
+	}
+	if _, fixes, err := Repair(b); err != nil {
+		return Node{}, err
+	} else {
+		for _, f := range fixes {
+			if !mode.allows(f.Kind) {
+				return Node{}, &SyntaxError{Offset: f.Offset, Msg: f.Kind.String() + " not allowed in " + mode.String() + " mode"}
+			}
+		}
+	}
+	return FromBytesMode(b, mode), nil
+}
+
+// FromBytesMode 同 FromBytes，但按 mode 扫描（不做校验）
+func FromBytesMode(b []byte, mode Mode) Node {
+	if mode == Strict {
+		return FromBytes(b)
+	}
+	i := lenientSkip(b, 0)
+	if i >= len(b) {
+		return Node{}
+	}
+	return Node{raw: b, start: i, end: lenientValueEnd(b, i), typ: lenientType(b[i]), mode: mode}
+}
+
+// Mode 返回节点的扫描模式
+func (n Node) Mode() Mode { return n.mode }
+
+// lenientSkip 跳过空白与注释
+func lenientSkip(b []byte, i int) int {
+	for i < len(b) {
+		switch c := b[i]; {
+		case wsTable[c]:
+			i++
+		case c == '/' && i+1 < len(b) && b[i+1] == '/':
+			for i < len(b) && b[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(b) && b[i+1] == '*':
+			i += 2
+			for i+1 < len(b) && !(b[i] == '*' && b[i+1] == '/') {
+				i++
+			}
+			i += 2
+		default:
+			return i
+		}
+	}
+	return len(b)
+}
+
+func lenientType(c byte) byte {
+	switch c {
+	case '{':
+		return 'o'
+	case '[':
+		return 'a'
+	case '"', '\'':
+		return 's'
+	case 't', 'f':
+		return 'b'
+	case 'n':
+		return 'l'
+	}
+	return 'n'
+}
+
+// lenientStringEnd 返回从引号 b[i]（双引号或单引号）开始的字符串结束后的位置
+func lenientStringEnd(b []byte, i int) int {
+	q := b[i]
+	for i++; i < len(b); i++ {
+		switch b[i] {
+		case '\\':
+			i++
+		case q:
+			return i + 1
+		}
+	}
+	return len(b)
+}
+
+// lenientValueEnd 返回从 i 开始的值结束后的位置，容器内会跳过字符串与注释
+func lenientValueEnd(b []byte, i int) int {
+	switch b[i] {
+	case '"', '\'':
+		return lenientStringEnd(b, i)
+	case '{', '[':
+		depth := 0
+		for i < len(b) {
+			switch c := b[i]; c {
+			case '"', '\'':
+				i = lenientStringEnd(b, i)
+				continue
+			case '/':
+				if j := lenientSkip(b, i); j > i {
+					i = j
+					continue
+				}
+			case '{', '[':
+				depth++
+			case '}', ']':
+				if depth--; depth == 0 {
+					return i + 1
+				}
+			}
+			i++
+		}
+		return len(b)
+	}
+	for i < len(b) {
+		switch c := b[i]; {
+		case wsTable[c], c == ',', c == ']', c == '}', c == '/':
+			return i
+		}
+		i++
+	}
+	return i
+}
+
+// lenientMember 读取对象中从 i 开始的下一个成员
+func (n Node) lenientMember(i int) (key []byte, v Node, next int, ok bool) {
+	b := n.raw
+	if i = lenientSkip(b, i); i >= n.end || b[i] == '}' {
+		return nil, Node{}, i, false
+	}
+	switch b[i] {
+	case '"', '\'':
+		e := lenientStringEnd(b, i)
+		key, i = b[i+1:e-1], e
+	default:
+		j := i
+		for j < n.end && isIdentPart(b[j]) {
+			j++
+		}
+		if j == i {
+			return nil, Node{}, i, false
+		}
+		key, i = b[i:j], j
+	}
+	if i = lenientSkip(b, i); i >= n.end || b[i] != ':' {
+		return nil, Node{}, i, false
+	}
+	if i = lenientSkip(b, i+1); i >= n.end {
+		return nil, Node{}, i, false
+	}
+	v = Node{raw: b, start: i, end: lenientValueEnd(b, i), typ: lenientType(b[i]), mode: n.mode}
+	if i = lenientSkip(b, v.end); i < n.end && b[i] == ',' {
+		i++
+	}
+	return key, v, i, true
+}
+
+// lenientElem 读取数组中从 i 开始的下一个元素
+func (n Node) lenientElem(i int) (v Node, next int, ok bool) {
+	b := n.raw
+	if i = lenientSkip(b, i); i >= n.end || b[i] == ']' {
+		return Node{}, i, false
+	}
+	v = Node{raw: b, start: i, end: lenientValueEnd(b, i), typ: lenientType(b[i]), mode: n.mode}
+	if i = lenientSkip(b, v.end); i < n.end && b[i] == ',' {
+		i++
+	}
+	return v, i, true
+}
+
+func (n Node) lenientGet(key []byte) (Node, bool) {
+	if n.typ != 'o' {
+		return Node{}, false
+	}
+	policy := GetDupPolicy()
+	var found Node
+	for i := n.start + 1; ; {
+		k, v, next, ok := n.lenientMember(i)
+		if !ok {
+			return found, found.typ != 0
+		}
+		if string(k) == string(key) {
+			switch {
+			case found.typ == 0:
+				found = v
+				if policy == DupFirst {
+					return found, true
+				}
+			case policy == DupError:
+				return Node{}, false
+			default:
+				found = v
+			}
+		}
+		i = next
+	}
+}
+
+func (n Node) lenientIndex(target int) (Node, bool) {
+	if n.typ != 'a' || target < 0 {
+		return Node{}, false
+	}
+	for i, idx := n.start+1, 0; ; idx++ {
+		v, next, ok := n.lenientElem(i)
+		if !ok {
+			return Node{}, false
+		}
+		if idx == target {
+			return v, true
+		}
+		i = next
+	}
+}
+
+func (n Node) lenientForEachObject(fn func(k []byte, v Node) bool) {
+	if n.typ != 'o' {
+		return
+	}
+	for i := n.start + 1; ; {
+		k, v, next, ok := n.lenientMember(i)
+		if !ok || !fn(k, v) {
+			return
+		}
+		i = next
+	}
+}
+
+func (n Node) lenientForEachArray(fn func(idx int, v Node) bool) {
+	if n.typ != 'a' {
+		return
+	}
+	for i, idx := n.start+1, 0; ; idx++ {
+		v, next, ok := n.lenientElem(i)
+		if !ok || !fn(idx, v) {
+			return
+		}
+		i = next
+	}
+}
+
+// lenientNumber 解析 JSON5 数字：十六进制、+1、.5、1.
+func lenientNumber(b []byte) (int64, float64, bool, bool) {
+	s := string(b)
+	body := s
+	if len(body) > 0 && (body[0] == '+' || body[0] == '-') {
+		body = body[1:]
+	}
+	if len(body) > 2 && body[0] == '0' && (body[1] == 'x' || body[1] == 'X') {
+		u, err := strconv.ParseUint(body[2:], 16, 64)
+		if err != nil || u > 1<<63 || (u == 1<<63 && s[0] != '-') {
+			return 0, 0, false, false
+		}
+		v := int64(u)
+		if s[0] == '-' {
+			v = -v
+		}
+		return v, float64(v), true, true
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, 0, false, false
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, f, true, true
+	}
+	return 0, f, false, true
+}
+
+// lenientJSONBytes 把宽松输入规范化为合法 JSON 拷贝，供基于 gjson 的 API 使用
+func (n Node) lenientJSONBytes() []byte {
+	out, _, err := Repair(n.Raw())
+	if err != nil {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package zeronode
+
+import "testing"
+
+func TestParseLenient(t *testing.T) {
+	cfg := []byte(`// service config
+{
+  "name": "api", /* inline } ] */
+  "ports": [80, 443,], // trailing
+  "db": {"host": "h//x",},
+}`)
+	if _, err := Parse(cfg, Strict); err == nil {
+		t.Fatal("strict should reject comments")
+	}
+	n, err := Parse(cfg, JSONC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Get("name").String() != "api" || n.GetPath("db", "host").String() != "h//x" {
+		t.Fatalf("lookup failed: %s", n.Raw())
+	}
+	var ports []int64
+	n.Get("ports").ForEachArray(func(_ int, v Node) bool {
+		p, _ := v.Int()
+		ports = append(ports, p)
+		return true
+	})
+	if len(ports) != 2 || ports[1] != 443 {
+		t.Fatalf("ports = %v", ports)
+	}
+	if v, ok := n.Get("ports").ArrayIndex(1); !ok || string(v.Raw()) != "443" {
+		t.Fatal("ArrayIndex failed")
+	}
+	if err := Validate(n.JSONBytes()); err != nil {
+		t.Fatalf("JSONBytes not normalized: %v", err)
+	}
+
+	for _, m := range []Mode{JSONC, JSON5} {
+		if _, err := Parse([]byte("{\"a\":1, # x\n \"b\":2}"), m); err == nil {
+			t.Fatalf("%s should reject # comments", m)
+		}
+	}
+
+	j5 := []byte(`{mask: 0xFF, 'quoted': 'it\'s', neg: -0x10, half: .5, /* c */ list: [1,],}`)
+	if _, err := Parse(j5, JSONC); err == nil {
+		t.Fatal("jsonc should reject unquoted keys")
+	}
+	n, err = Parse(j5, JSON5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := n.Get("mask").Int(); v != 255 {
+		t.Fatalf("mask = %d", v)
+	}
+	if v, _ := n.Get("neg").Int(); v != -16 {
+		t.Fatalf("neg = %d", v)
+	}
+	if f, _ := n.Get("half").Float(); f != 0.5 {
+		t.Fatalf("half = %v", f)
+	}
+	if s := n.Get("quoted").UnescapedString(); s != "it's" {
+		t.Fatalf("quoted = %q", s)
+	}
+	keys := 0
+	n.ForEachObject(func([]byte, Node) bool { keys++; return true })
+	if keys != 5 {
+		t.Fatalf("keys = %d", keys)
+	}
+	if _, err := Parse([]byte(`{a: NaN}`), JSON5); err == nil {
+		t.Fatal("NaN should be rejected")
+	}
+
+	// \' 不是标准 JSON 转义
+	sq := []byte(`{"s":"it\'s"}`)
+	if _, err := Parse(sq, Strict); err == nil {
+		t.Fatal("strict should reject \\'")
+	}
+	if s := FromBytes(sq).Get("s").UnescapedString(); s != "its" {
+		t.Fatalf("strict unescape = %q", s)
+	}
+	if s := FromBytesMode(sq, JSON5).Get("s").UnescapedString(); s != "it's" {
+		t.Fatalf("json5 unescape = %q", s)
+	}
+}
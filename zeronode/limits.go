@@ -0,0 +1,236 @@
+package zeronode
+
+import (
+	"strconv"
+	"sync/atomic"
+
+	"github.com/icloudza/gcjson/errs"
+)
+
+// 默认上限：与此前查询入口的 convert.MaxJSONSize / raw.MaxJSONSize 相同的 10MB，
+// 嵌套层数与 encoding/json 相同；其余默认不限制。
+const (
+	DefaultMaxBytes = 10 << 20
+	DefaultMaxDepth = 10000
+)
+
+// Limits 输入规模上限，字段为 0 表示不限制。
+//
+// 引擎级：SetLimits 设置全局值，根包查询、raw、parser 物化与 structfast 解码统一遵循；
+// 单次调用：Limits.Check 显式检查一段输入，parser.NativeOptions.Limits 与
+// structfast.Options.Limits 覆盖单次物化 / 解码使用的上限。
+//
+// 路径查询（raw.Get、根包 Get / Any 定位路径的阶段）只在入口检查 MaxBytes，不检查层数、成员数等其余各项，
+// 也就不为取一个字段扫描整个输入；Node.Get 等节点方法不检查任何上限。
+// 其余各项在物化（ToNative、Any 返回 map / 切片、structfast 解码）与 Validate 时检查，
+// 只物化某个路径下的子树时，层数与计数从该子树的根开始。
+// 显式传给物化 / 解码的 Limits 全部生效，包括 MaxBytes。
+type Limits struct {
+	MaxBytes      int // 输入总字节数
+	MaxDepth      int // 对象 / 数组嵌套层数
+	MaxObjectKeys int // 单个对象的成员数（重复键分别计数）
+	MaxArrayLen   int // 单个数组的元素数
+	MaxStringLen  int // 单个字符串（含键）转义前的字节数
+	MaxNodes      int // 值的总数，对象、数组、标量各计一个
+}
+
+// DefaultLimits 返回默认上限
+func DefaultLimits() Limits {
+	return Limits{MaxBytes: DefaultMaxBytes, MaxDepth: DefaultMaxDepth}
+}
+
+var limits atomic.Pointer[Limits]
+
+func init() {
+	l := DefaultLimits()
+	limits.Store(&l)
+}
+
+// SetLimits 设置全局上限
+func SetLimits(l Limits) { limits.Store(&l) }
+
+// GetLimits 返回当前全局上限
+func GetLimits() Limits { return *limits.Load() }
+
+// 超限项名称，见 LimitError.Limit
+const (
+	LimitBytes      = "bytes"
+	LimitDepth      = "depth"
+	LimitObjectKeys = "object keys"
+	LimitArrayLen   = "array length"
+	LimitStringLen  = "string length"
+	LimitNodes      = "nodes"
+)
+
+// LimitError 输入超出 Limits 的某一项。
+// 可用 errors.Is(err, errs.ErrLimitExceeded) 判断；MaxBytes 超限同时匹配 errs.ErrTooLarge。
+type LimitError struct {
+	Limit  string // 超限项，如 LimitDepth
+	Max    int    // 上限值
+	Offset int    // 超限位置在输入中的字节偏移，未知时为 -1
+}
+
+func (e *LimitError) Error() string {
+	s := "zeronode: " + e.Limit + " exceeds limit " + strconv.Itoa(e.Max)
+	if e.Offset >= 0 {
+		s += " at offset " + strconv.Itoa(e.Offset)
+	}
+	return s
+}
+
+func (e *LimitError) Is(target error) bool {
+	return target == errs.ErrLimitExceeded || (e.Limit == LimitBytes && target == errs.ErrTooLarge)
+}
+
+// CheckSize 只检查 MaxBytes
+func (l Limits) CheckSize(n int) error {
+	if l.MaxBytes > 0 && n > l.MaxBytes {
+		return &LimitError{Limit: LimitBytes, Max: l.MaxBytes, Offset: l.MaxBytes}
+	}
+	return nil
+}
+
+// Check 单遍扫描 b 并检查全部上限，不做完整语法校验（配合 Validate 使用）。
+// 扫描是迭代的，任意深度的输入都不会耗尽栈。
+func (l Limits) Check(b []byte) error {
+	if err := l.CheckSize(len(b)); err != nil {
+		return err
+	}
+	var bud Budget
+	bud.Reset(l)
+	var stack []limitFrame
+	// value 记录一个值：计入所在数组的长度，再检查深度 / 字符串长度 / 节点数
+	value := func(depth, strLen, off int) error {
+		if top := len(stack) - 1; top >= 0 && !stack[top].obj {
+			stack[top].count++
+			if err := bud.Members('a', stack[top].count, off); err != nil {
+				return err
+			}
+		}
+		return bud.Value(depth, strLen, off)
+	}
+	for i := 0; i < len(b); i++ {
+		switch c := b[i]; c {
+		case ' ', '\t', '\n', '\r', ':':
+		case ',':
+			if top := len(stack) - 1; top >= 0 && stack[top].obj {
+				stack[top].wantKey = true
+			}
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case '{', '[':
+			if err := value(len(stack)+1, -1, i); err != nil {
+				return err
+			}
+			stack = append(stack, limitFrame{obj: c == '{', wantKey: c == '{'})
+		case '"':
+			j := i + 1
+			for j < len(b) && b[j] != '"' {
+				if b[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if top := len(stack) - 1; top >= 0 && stack[top].obj && stack[top].wantKey {
+				// 对象键
+				stack[top].wantKey = false
+				stack[top].count++
+				if err := bud.Members('o', stack[top].count, i); err != nil {
+					return err
+				}
+				if err := bud.Key(j-i-1, i); err != nil {
+					return err
+				}
+			} else if err := value(len(stack), j-i-1, i); err != nil {
+				return err
+			}
+			i = j
+		default:
+			// 数字 / 字面量：跳到分隔符
+			if err := value(len(stack), -1, i); err != nil {
+				return err
+			}
+			for i+1 < len(b) && !isDelim(b[i+1]) {
+				i++
+			}
+		}
+	}
+	return nil
+}
+
+type limitFrame struct {
+	obj     bool
+	count   int
+	wantKey bool // 对象中下一个字符串是键
+}
+
+func isDelim(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', ',', ':', '}', ']', '{', '[', '"':
+		return true
+	}
+	return false
+}
+
+// Budget 物化 / 解码过程中的计数器，供 parser、structfast 递归时逐项检查。
+// 首次超限后的调用都返回同一个错误。
+type Budget struct {
+	l     Limits
+	nodes int
+	err   error
+}
+
+// Reset 以 l 重新开始计数
+func (b *Budget) Reset(l Limits) { *b = Budget{l: l} }
+
+// Limits 返回计数所用的上限
+func (b *Budget) Limits() Limits { return b.l }
+
+// Value 每遇到一个值调用一次。depth 为嵌套层数：对象 / 数组计入自身（根对象为 1），
+// 标量为包含它的容器层数（根标量为 0）；strLen 为字符串转义前的字节数（非字符串传 -1）；
+// offset 为偏移（未知传 -1）。
+func (b *Budget) Value(depth, strLen, offset int) error {
+	if b.err != nil {
+		return b.err
+	}
+	switch l := &b.l; {
+	case l.MaxDepth > 0 && depth > l.MaxDepth:
+		b.err = &LimitError{Limit: LimitDepth, Max: l.MaxDepth, Offset: offset}
+	case l.MaxStringLen > 0 && strLen > l.MaxStringLen:
+		b.err = &LimitError{Limit: LimitStringLen, Max: l.MaxStringLen, Offset: offset}
+	case l.MaxNodes > 0 && b.nodes >= l.MaxNodes:
+		b.err = &LimitError{Limit: LimitNodes, Max: l.MaxNodes, Offset: offset}
+	default:
+		b.nodes++
+	}
+	return b.err
+}
+
+// Members 容器（kind 为 'o' 或 'a'）已读到第 n 个成员时调用
+func (b *Budget) Members(kind byte, n, offset int) error {
+	if b.err != nil {
+		return b.err
+	}
+	if kind == 'o' && b.l.MaxObjectKeys > 0 && n > b.l.MaxObjectKeys {
+		b.err = &LimitError{Limit: LimitObjectKeys, Max: b.l.MaxObjectKeys, Offset: offset}
+	} else if kind == 'a' && b.l.MaxArrayLen > 0 && n > b.l.MaxArrayLen {
+		b.err = &LimitError{Limit: LimitArrayLen, Max: b.l.MaxArrayLen, Offset: offset}
+	}
+	return b.err
+}
+
+// Key 对象键：只检查长度，不计入节点数
+func (b *Budget) Key(strLen, offset int) error {
+	if b.err == nil && b.l.MaxStringLen > 0 && strLen > b.l.MaxStringLen {
+		b.err = &LimitError{Limit: LimitStringLen, Max: b.l.MaxStringLen, Offset: offset}
+	}
+	return b.err
+}
+
+// Nodes 已计入的节点数
+func (b *Budget) Nodes() int { return b.nodes }
+
+// Err 首个超限错误
+func (b *Budget) Err() error { return b.err }
@@ -0,0 +1,55 @@
+package zeronode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/icloudza/gcjson/errs"
+)
+
+func TestLimitsCheck(t *testing.T) {
+	deep := []byte(strings.Repeat("[", 100) + strings.Repeat("]", 100))
+	for _, c := range []struct {
+		in    string
+		l     Limits
+		limit string // 空表示不超限
+	}{
+		{string(deep), Limits{MaxDepth: 100}, ""},
+		{string(deep), Limits{MaxDepth: 99}, LimitDepth},
+		{`{"a":{"b":1}}`, Limits{MaxDepth: 2}, ""},
+		{`{"a":1,"b":2,"c":3}`, Limits{MaxObjectKeys: 2}, LimitObjectKeys},
+		{`[1,[2,3],4]`, Limits{MaxArrayLen: 3}, ""},
+		{`[1,2,3,4]`, Limits{MaxArrayLen: 3}, LimitArrayLen},
+		{`{"k":"abcd"}`, Limits{MaxStringLen: 3}, LimitStringLen},
+		{`{"abcd":1}`, Limits{MaxStringLen: 3}, LimitStringLen},
+		{`{"a":"x\"y"}`, Limits{MaxStringLen: 4}, ""},
+		{`{"a":[1,2]}`, Limits{MaxNodes: 4}, ""},
+		{`{"a":[1,2,3]}`, Limits{MaxNodes: 4}, LimitNodes},
+		{`[1,2]`, Limits{MaxBytes: 4}, LimitBytes},
+	} {
+		err := c.l.Check([]byte(c.in))
+		var le *LimitError
+		switch {
+		case c.limit == "" && err != nil:
+			t.Fatalf("%s %+v: %v", c.in, c.l, err)
+		case c.limit != "" && (!errors.As(err, &le) || le.Limit != c.limit || !errors.Is(err, errs.ErrLimitExceeded)):
+			t.Fatalf("%s %+v: want %s, got %v", c.in, c.l, c.limit, err)
+		}
+	}
+	if err := (Limits{MaxBytes: 1}).Check([]byte("[]")); !errors.Is(err, errs.ErrTooLarge) {
+		t.Fatalf("bytes limit should match ErrTooLarge: %v", err)
+	}
+}
+
+func TestValidateDepth(t *testing.T) {
+	defer SetLimits(GetLimits())
+	in := []byte(strings.Repeat(`{"a":`, 50) + "1" + strings.Repeat("}", 50))
+	if err := Validate(in); err != nil {
+		t.Fatal(err)
+	}
+	SetLimits(Limits{MaxDepth: 49})
+	if err := Validate(in); !errors.Is(err, errs.ErrLimitExceeded) {
+		t.Fatalf("depth limit not enforced: %v", err)
+	}
+}
@@ -1,8 +1,9 @@
 package zeronode
 
 import (
-	"bytes"
+	"encoding/json"
 	"github.com/icloudza/gcjson/fast"
+	"math"
 	"unicode/utf8"
 	"unsafe"
 )
@@ -24,12 +25,16 @@ type Node struct {
 	start int    // 节点值起始索引
 	end   int    // 节点值结束索引（不包含）
 	typ   byte   // 节点类型
+	mode  Mode   // 扫描模式，见 lenient.go
 }
 
 func New(b []byte) Node { return Node{raw: b} }
 
 func (n Node) RawBytes() []byte { return n.raw }
 
+// Offset 节点在所属输入中的字节偏移
+func (n Node) Offset() int { return n.start }
+
 //
 // ========================= 构造与类型判断 =========================
 //
@@ -51,64 +56,15 @@ func (n Node) Type() byte { return n.typ }
 
 // ObjectKey 按 key 找子节点；失败返回 false
 func (n Node) ObjectKey(k string) (Node, bool) {
-	if n.typ != 'o' {
-		return Node{}, false
-	}
-	// 遍历对象找 key
-	i := n.start + 1 // 跳过 '{'
-	for i < n.end {
-		i = skipSpaces(n.raw, i)
-		if i >= n.end || n.raw[i] == '}' {
-			break
-		}
-		if n.raw[i] != '"' {
-			return Node{}, false
-		}
-		ks := i + 1
-		i++
-		for i < n.end && n.raw[i] != '"' {
-			if n.raw[i] == '\\' {
-				i++
-			}
-			i++
-		}
-		ke := i
-		i++
-		i = skipSpaces(n.raw, i)
-		if i >= n.end || n.raw[i] != ':' {
-			return Node{}, false
-		}
-		i++
-		i = skipSpaces(n.raw, i)
-
-		valStart, typ := skipWS(n.raw, i)
-		valEnd := findValueEnd(n.raw, valStart)
-
-		// 直接匹配 key
-		if ke-ks == len(k) {
-			match := true
-			for j := 0; j < len(k); j++ {
-				if n.raw[ks+j] != k[j] {
-					match = false
-					break
-				}
-			}
-			if match {
-				return Node{raw: n.raw, start: valStart, end: valEnd, typ: typ}, true
-			}
-		}
-
-		i = valEnd
-		i = skipSpaces(n.raw, i)
-		if i < n.end && n.raw[i] == ',' {
-			i++
-		}
-	}
-	return Node{}, false
+	v := n.getBytes([]byte(k))
+	return v, v.typ != 0
 }
 
 // ArrayIndex 按 idx 找子节点；失败返回 false
 func (n Node) ArrayIndex(target int) (Node, bool) {
+	if n.mode != Strict {
+		return n.lenientIndex(target)
+	}
 	if target < 0 {
 		return Node{}, false
 	}
@@ -187,12 +143,12 @@ func (n Node) UnescapedString() string {
 	if n.typ != 's' {
 		return ""
 	}
-	b := unescapeJSONString(n.raw[n.start+1 : n.end-1])
+	b := unescapeJSONString(n.raw[n.start+1:n.end-1], n.mode)
 	return string(b)
 }
 
 // Int 尝试解析节点为整数。
-// 返回值：整数值、是否成功。
+// 返回值：整数值、是否成功；非整数字面量或超出 int64 范围时失败。
 func (n Node) Int() (int64, bool) {
 	if n.typ != 'n' {
 		return 0, false
@@ -201,38 +157,65 @@ func (n Node) Int() (int64, bool) {
 	if len(b) == 0 {
 		return 0, false
 	}
-	neg := b[0] == '-'
-	i := 0
-	if neg {
-		if len(b) == 1 {
-			return 0, false
-		}
-		i = 1
+	if n.mode == JSON5 {
+		i, _, isInt, ok := lenientNumber(b)
+		return i, ok && isInt
 	}
-	var v int64
-	for ; i < len(b); i++ {
-		c := b[i]
-		if c < '0' || c > '9' {
+	if b[0] != '-' {
+		u, ok := parseUintDigits(b)
+		if !ok || u > math.MaxInt64 {
 			return 0, false
 		}
-		v = v*10 + int64(c-'0')
+		return int64(u), true
 	}
-	if neg {
-		v = -v
+	u, ok := parseUintDigits(b[1:])
+	if !ok || u > 1<<63 {
+		return 0, false
 	}
-	return v, true
+	return -int64(u), true
+}
+
+// Uint64 尝试解析节点为无符号整数，可表示大于 MaxInt64 的值。
+// 返回值：整数值、是否成功；负数、非整数字面量或溢出时失败。
+func (n Node) Uint64() (uint64, bool) {
+	if n.typ != 'n' {
+		return 0, false
+	}
+	if n.mode == JSON5 {
+		if i, _, isInt, ok := lenientNumber(n.raw[n.start:n.end]); ok && isInt && i >= 0 {
+			return uint64(i), true
+		}
+	}
+	return parseUintDigits(n.raw[n.start:n.end])
 }
 
-// Float 尝试解析节点为浮点数。
-// 返回值：浮点值、是否成功。
+// Float 尝试解析节点为浮点数，结果为正确舍入的最近 float64。
+// 返回值：浮点值、是否成功。需要保留全部精度时用 Number。
 func (n Node) Float() (float64, bool) {
 	if n.typ != 'n' {
 		return 0, false
 	}
+	if n.mode == JSON5 {
+		_, f, _, ok := lenientNumber(n.raw[n.start:n.end])
+		return f, ok
+	}
 	f, ok := fast.ParseFloat(n.raw[n.start:n.end])
 	return f, ok
 }
 
+// Number 返回数字节点的原文（json.Number），不做任何转换，不丢精度。
+func (n Node) Number() (json.Number, bool) {
+	if n.typ != 'n' {
+		return "", false
+	}
+	if n.mode == JSON5 {
+		// 0x1F、+1、.5 等先规范化为 JSON 数字
+		b, _, err := Repair(n.raw[n.start:n.end])
+		return json.Number(b), err == nil
+	}
+	return json.Number(n.raw[n.start:n.end]), true
+}
+
 // Bool 尝试解析节点为布尔值。
 // 返回值：布尔值、是否成功。
 func (n Node) Bool() (bool, bool) {
@@ -257,11 +240,17 @@ func (n Node) Get(key string) Node { return n.getBytes([]byte(key)) }
 // 如果节点不是对象，返回空节点。
 func (n Node) GetBytes(key []byte) Node { return n.getBytes(key) }
 
-// getBytes 是 Get/GetBytes 的核心实现。
+// getBytes 是 Get/GetBytes/ObjectKey 的核心实现，重复键按全局 DupPolicy 处理。
 func (n Node) getBytes(key []byte) Node {
+	if n.mode != Strict {
+		v, _ := n.lenientGet(key)
+		return v
+	}
 	if n.typ != 'o' {
 		return Node{}
 	}
+	policy := GetDupPolicy()
+	var found Node
 	i := n.start + 1 // 跳过 '{'
 	for i < n.end {
 		i = skipSpaces(n.raw, i)
@@ -269,7 +258,7 @@ func (n Node) getBytes(key []byte) Node {
 			break
 		}
 		if n.raw[i] != '"' {
-			return Node{}
+			return found
 		}
 		ks := i + 1
 		i++
@@ -283,14 +272,24 @@ func (n Node) getBytes(key []byte) Node {
 		i++
 		i = skipSpaces(n.raw, i)
 		if i >= n.end || n.raw[i] != ':' {
-			return Node{}
+			return found
 		}
 		i++
 		i = skipSpaces(n.raw, i)
 		valStart, typ := skipWS(n.raw, i)
 		valEnd := findValueEnd(n.raw, valStart)
-		if bytes.Equal(n.raw[ks:ke], key) {
-			return Node{raw: n.raw, start: valStart, end: valEnd, typ: typ}
+		if keyEqual(n.raw[ks:ke], key) {
+			switch {
+			case found.typ == 0:
+				found = Node{raw: n.raw, start: valStart, end: valEnd, typ: typ}
+				if policy == DupFirst {
+					return found
+				}
+			case policy == DupError:
+				return Node{}
+			default:
+				found = Node{raw: n.raw, start: valStart, end: valEnd, typ: typ}
+			}
 		}
 		i = valEnd
 		i = skipSpaces(n.raw, i)
@@ -298,7 +297,7 @@ func (n Node) getBytes(key []byte) Node {
 			i++
 		}
 	}
-	return Node{}
+	return found
 }
 
 // GetPath 按路径链式访问节点。
@@ -324,6 +323,20 @@ func (n Node) GetManyInto(keys [][]byte, out []Node) int {
 	for i := range keys {
 		out[i].typ = 0
 	}
+	if n.mode != Strict {
+		found := 0
+		n.lenientForEachObject(func(k []byte, v Node) bool {
+			for idx := range keys {
+				if out[idx].typ == 0 && string(k) == string(keys[idx]) {
+					out[idx] = v
+					found++
+					break
+				}
+			}
+			return found < len(keys)
+		})
+		return found
+	}
 
 	remain := len(keys)
 	i := n.start + 1 // 跳过 '{'
@@ -393,6 +406,9 @@ func (n Node) GetManyInto(keys [][]byte, out []Node) int {
 // 零分配，适合性能敏感场景。
 // GetPathFast 一次扫描完成多级路径解析（比递归 Get 快很多）
 func (n Node) GetPathFast(keys ...string) Node {
+	if n.mode != Strict {
+		return n.GetPath(keys...)
+	}
 	cur := n
 	for depth, key := range keys {
 		if cur.typ != 'o' {
@@ -465,6 +481,10 @@ func (n Node) GetPathFast(keys ...string) Node {
 // ForEachObject 遍历对象的所有 key-value 对。
 // 回调函数返回 false 时中止遍历。
 func (n Node) ForEachObject(fn func(k []byte, v Node) bool) {
+	if n.mode != Strict {
+		n.lenientForEachObject(fn)
+		return
+	}
 	if n.typ != 'o' {
 		return
 	}
@@ -509,6 +529,10 @@ func (n Node) ForEachObject(fn func(k []byte, v Node) bool) {
 // ForEachArray 遍历数组的所有元素。
 // 回调函数返回 false 时中止遍历。
 func (n Node) ForEachArray(fn func(idx int, v Node) bool) {
+	if n.mode != Strict {
+		n.lenientForEachArray(fn)
+		return
+	}
 	if n.typ != 'a' {
 		return
 	}
@@ -541,6 +565,15 @@ func (n Node) Raw() []byte {
 	return n.raw[n.start:n.end]
 }
 
+// JSONBytes 实现 convert.Source，使 Node 可直接作为 gcjson / raw 的输入。
+// Strict 模式下同 Raw；JSONC / JSON5 模式下返回去掉注释等之后的合法 JSON 拷贝。
+func (n Node) JSONBytes() []byte {
+	if n.mode != Strict {
+		return n.lenientJSONBytes()
+	}
+	return n.Raw()
+}
+
 //
 // ========================= 内部工具 =========================
 //
@@ -727,6 +760,25 @@ func scanNumber(b []byte, i int) (int, bool) {
 	return i, true
 }
 
+// parseUintDigits 解析纯数字串为 uint64，带溢出检查。
+func parseUintDigits(b []byte) (uint64, bool) {
+	if len(b) == 0 {
+		return 0, false
+	}
+	var v uint64
+	for i := 0; i < len(b); i++ {
+		c := b[i] - '0'
+		if c > 9 {
+			return 0, false
+		}
+		if v > (math.MaxUint64-uint64(c))/10 {
+			return 0, false
+		}
+		v = v*10 + uint64(c)
+	}
+	return v, true
+}
+
 // findValueEnd 查找 JSON 值的结束位置。
 // 从值的起始位置开始，扫描直到值的末尾。
 func findValueEnd(b []byte, i int) int {
@@ -769,9 +821,10 @@ func findValueEnd(b []byte, i int) int {
 			}
 		}
 	default:
+		// 数字 / true / false / null：遇到分隔符或空白即结束
 		for ; i < len(b); i++ {
 			switch b[i] {
-			case ',', '}', ']':
+			case ',', '}', ']', ' ', '\n', '\r', '\t':
 				return i
 			}
 		}
@@ -779,8 +832,8 @@ func findValueEnd(b []byte, i int) int {
 	return i
 }
 
-// unescapeJSONString 反转义 JSON 字符串（\n、\uXXXX 等）。
-func unescapeJSONString(b []byte) []byte {
+// unescapeJSONString 反转义 JSON 字符串（\n、\uXXXX 等）；\' 仅在非 Strict 模式下有效。
+func unescapeJSONString(b []byte, mode Mode) []byte {
 	var out []byte
 	for i := 0; i < len(b); i++ {
 		if b[i] != '\\' {
@@ -794,6 +847,10 @@ func unescapeJSONString(b []byte) []byte {
 		switch b[i] {
 		case '"', '\\', '/':
 			out = append(out, b[i])
+		case '\'':
+			if mode != Strict {
+				out = append(out, b[i])
+			}
 		case 'b':
 			out = append(out, '\b')
 		case 'f':
@@ -0,0 +1,42 @@
+package zeronode
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestFloatCorrectlyRounded(t *testing.T) {
+	cases := []string{
+		"0.1", "0.3", "1.7976931348623157e308", "2.2250738585072014e-308", "5e-324",
+		"9007199254740993", "123456789012345678901234567890", "0.1e-22", "1e23",
+		"8.41e21", "3.0000000000000004", "-0", "1e-400", "4.9406564584124654e-324",
+		"2.718281828459045235360287471352662497757", "100000000000000000000000000e-26",
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		f := r.NormFloat64() * float64(int64(1)<<r.Intn(60))
+		cases = append(cases, strconv.FormatFloat(f, 'g', 17, 64), strconv.FormatFloat(f, 'e', r.Intn(20), 64))
+	}
+	for _, c := range cases {
+		want, err := strconv.ParseFloat(c, 64)
+		got, ok := FromBytes([]byte(c)).Float()
+		if ok != (err == nil) || (ok && got != want) {
+			t.Fatalf("%s: got %v,%v want %v,%v", c, got, ok, want, err)
+		}
+	}
+	if _, ok := FromBytes([]byte("1e400")).Float(); ok {
+		t.Fatal("overflow should fail")
+	}
+}
+
+func TestNumber(t *testing.T) {
+	n := FromBytes([]byte(`{"id":12345678901234567890123,"h":0x1F}`))
+	if v, ok := n.Get("id").Number(); !ok || v != "12345678901234567890123" {
+		t.Fatalf("Number = %v, %v", v, ok)
+	}
+	j, _ := Parse([]byte(`{"h":0x1F}`), JSON5)
+	if v, ok := j.Get("h").Number(); !ok || v != "31" {
+		t.Fatalf("JSON5 Number = %v, %v", v, ok)
+	}
+}
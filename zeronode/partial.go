@@ -0,0 +1,261 @@
+package zeronode
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Partial 是 ParsePartial 的结果：把被截断的 JSON 补全为合法 JSON 后的根节点，
+// 以及尚未结束的值的路径。已完整的字段可以像普通 Node 一样读取，
+// 也可以把 Partial 直接传给 raw.GetBytes / gcjson.Any。
+type Partial struct {
+	Node
+	// Incomplete 未结束的字符串、数字、数组、对象的路径（gjson 点分风格，根为 ""），外层在前
+	Incomplete []string
+}
+
+// Complete 输入是否为完整 JSON
+func (p Partial) Complete() bool { return len(p.Incomplete) == 0 }
+
+// IsIncomplete 报告 path 处的值是否仍在传输中（值本身或其内部被截断）
+func (p Partial) IsIncomplete(path string) bool {
+	for _, s := range p.Incomplete {
+		if s == path {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePartial 尽力解析可能被截断的 JSON（例如 LLM / SSE 流式输出的前缀）。
+//
+// 截断处理规则：
+//   - 未闭合的对象、数组补齐括号；未闭合的字符串保留已到达的内容并补齐引号
+//   - 容器内位于末尾的数字保留，但标记为未完成（后续可能还有数字）
+//   - 不完整的键、缺少值的键、不完整的 true/false/null 与数字前缀（如 "1."）被丢弃
+//
+// 输入本身完整时不拷贝；非截断造成的语法错误返回 *SyntaxError。
+func ParsePartial(b []byte) (Partial, error) {
+	p := partialParser{b: b, cut: -1}
+	i := skipSpaces(b, 0)
+	if i >= len(b) {
+		return Partial{Incomplete: []string{""}}, nil
+	}
+	end, st := p.value(i, "", 0)
+	switch {
+	case st == partialError:
+		return Partial{}, p.err
+	case st == partialDropped:
+		return Partial{Incomplete: []string{""}}, nil
+	case st == partialComplete:
+		if j := skipSpaces(b, end); j < len(b) {
+			return Partial{}, &SyntaxError{Offset: j, Msg: "unexpected data after top-level value"}
+		}
+		return Partial{Node: FromBytes(b)}, nil
+	}
+	out := make([]byte, 0, p.cut+len(p.suffix))
+	out = append(out, b[:p.cut]...)
+	out = append(out, p.suffix...)
+	// 收集时由内向外，翻转为外层在前
+	for l, r := 0, len(p.incomplete)-1; l < r; l, r = l+1, r-1 {
+		p.incomplete[l], p.incomplete[r] = p.incomplete[r], p.incomplete[l]
+	}
+	return Partial{Node: FromBytes(out), Incomplete: p.incomplete}, nil
+}
+
+// 值的解析状态
+const (
+	partialComplete = iota // 完整
+	partialKept            // 被截断但保留（已设置 cut/suffix）
+	partialDropped         // 被截断且整个丢弃
+	partialError           // 语法错误
+)
+
+type partialParser struct {
+	b          []byte
+	cut        int    // 保留 b[:cut]
+	suffix     []byte // 补全用的后缀（引号与括号）
+	incomplete []string
+	err        error
+}
+
+// truncated 判断错误是否由输入结束引起
+func (p *partialParser) truncated(err error) bool {
+	se, ok := err.(*SyntaxError)
+	return ok && se.Offset >= len(p.b)
+}
+
+func (p *partialParser) fail(err error) (int, int) {
+	p.err = err
+	return 0, partialError
+}
+
+func (p *partialParser) value(i int, path string, depth int) (int, int) {
+	b := p.b
+	switch c := b[i]; {
+	case c == '{':
+		return p.container(i, path, depth, '}')
+	case c == '[':
+		return p.container(i, path, depth, ']')
+	case c == '"':
+		end, err := validString(b, i)
+		if err == nil {
+			return end, partialComplete
+		}
+		if !p.truncated(err) {
+			return p.fail(err)
+		}
+		p.cut = safeStringCut(b, i+1)
+		p.suffix = append(p.suffix, '"')
+		p.incomplete = append(p.incomplete, path)
+		return len(b), partialKept
+	}
+	end, err := validValue(b, i)
+	if err != nil {
+		if p.truncated(err) {
+			return 0, partialDropped
+		}
+		return p.fail(err)
+	}
+	if end == len(b) && depth > 0 && b[i] != 't' && b[i] != 'f' && b[i] != 'n' {
+		p.cut = end
+		p.incomplete = append(p.incomplete, path)
+		return end, partialKept
+	}
+	return end, partialComplete
+}
+
+// container 解析对象（closer='}'）或数组（closer=']'）
+func (p *partialParser) container(i int, path string, depth int, closer byte) (int, int) {
+	b := p.b
+	last := i + 1 // 最后一个完整成员之后的位置
+	i = skipSpaces(b, i+1)
+	if i < len(b) && b[i] == closer {
+		return i + 1, partialComplete
+	}
+	for idx := 0; ; idx++ {
+		if i >= len(b) {
+			return p.close(last, path, closer)
+		}
+		var child string
+		if closer == '}' {
+			if b[i] != '"' {
+				return p.fail(invalidChar(b, i))
+			}
+			kEnd, err := validString(b, i)
+			if err != nil {
+				if p.truncated(err) {
+					return p.close(last, path, closer)
+				}
+				return p.fail(err)
+			}
+			child = joinPath(path, escapePathKey(FromBytes(b[i:kEnd]).UnescapedString()))
+			if i = skipSpaces(b, kEnd); i >= len(b) {
+				return p.close(last, path, closer)
+			}
+			if b[i] != ':' {
+				return p.fail(invalidChar(b, i))
+			}
+			if i = skipSpaces(b, i+1); i >= len(b) {
+				return p.close(last, path, closer)
+			}
+		} else {
+			child = joinPath(path, strconv.Itoa(idx))
+		}
+		end, st := p.value(i, child, depth+1)
+		switch st {
+		case partialError:
+			return 0, st
+		case partialDropped:
+			return p.close(last, path, closer)
+		case partialKept:
+			p.suffix = append(p.suffix, closer)
+			p.incomplete = append(p.incomplete, path)
+			return len(b), partialKept
+		}
+		last = end
+		if i = skipSpaces(b, end); i >= len(b) {
+			return p.close(last, path, closer)
+		}
+		switch b[i] {
+		case ',':
+			i = skipSpaces(b, i+1)
+		case closer:
+			return i + 1, partialComplete
+		default:
+			return p.fail(invalidChar(b, i))
+		}
+	}
+}
+
+// close 在 last 处截断并闭合当前容器
+func (p *partialParser) close(last int, path string, closer byte) (int, int) {
+	p.cut = last
+	p.suffix = append(p.suffix, closer)
+	p.incomplete = append(p.incomplete, path)
+	return len(p.b), partialKept
+}
+
+// safeStringCut 返回字符串内容中可安全保留的结束位置：不截断转义序列与多字节 UTF-8 字符
+func safeStringCut(b []byte, i int) int {
+	safe := i
+	for i < len(b) {
+		if b[i] == '\\' {
+			n, ok := escapeSeqLen(b[i:])
+			if !ok {
+				break
+			}
+			i += n
+		} else {
+			i++
+		}
+		safe = i
+	}
+	for k := 1; k < utf8.UTFMax && safe-k >= 0; k++ {
+		if utf8.RuneStart(b[safe-k]) {
+			if !utf8.FullRune(b[safe-k : safe]) {
+				safe -= k
+			}
+			break
+		}
+	}
+	return safe
+}
+
+// escapeSeqLen 返回完整转义序列长度；序列不完整时返回 false
+func escapeSeqLen(b []byte) (int, bool) {
+	if len(b) < 2 {
+		return 0, false
+	}
+	if b[1] != 'u' {
+		return 2, true
+	}
+	if len(b) < 6 {
+		return 0, false
+	}
+	return 6, true
+}
+
+func joinPath(path, seg string) string {
+	if path == "" {
+		return seg
+	}
+	return path + "." + seg
+}
+
+// escapePathKey 转义 gjson 路径中的特殊字符
+func escapePathKey(k string) string {
+	if !strings.ContainsAny(k, `.*?|#@\`) {
+		return k
+	}
+	var sb strings.Builder
+	for i := 0; i < len(k); i++ {
+		switch k[i] {
+		case '.', '*', '?', '|', '#', '@', '\\':
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(k[i])
+	}
+	return sb.String()
+}
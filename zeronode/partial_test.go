@@ -0,0 +1,43 @@
+package zeronode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePartial(t *testing.T) {
+	cases := []struct {
+		in, want   string
+		incomplete []string
+	}{
+		{`{"a":1,"b":[1,2]}`, `{"a":1,"b":[1,2]}`, nil},
+		{`{"name":"Al`, `{"name":"Al"}`, []string{"", "name"}},
+		{`{"a":1,"b":[1,{"c":tr`, `{"a":1,"b":[1,{}]}`, []string{"", "b", "b.1"}},
+		{`{"a":1,"b":[1,2`, `{"a":1,"b":[1,2]}`, []string{"", "b", "b.1"}},
+		{`{"a":1,"na`, `{"a":1}`, []string{""}},
+		{`{"a":1,"b":`, `{"a":1}`, []string{""}},
+		{`{"s":"x\u00`, `{"s":"x"}`, []string{"", "s"}},
+		{`["中`[:4], `[""]`, []string{"", "0"}},
+		{`{"a.b":{"x":"`, `{"a.b":{"x":""}}`, []string{"", `a\.b`, `a\.b.x`}},
+	}
+	for _, c := range cases {
+		p, err := ParsePartial([]byte(c.in))
+		if err != nil {
+			t.Fatalf("%s: %v", c.in, err)
+		}
+		if got := string(p.Raw()); got != c.want {
+			t.Fatalf("%s: got %s want %s", c.in, got, c.want)
+		}
+		if got, want := strings.Join(p.Incomplete, "|"), strings.Join(c.incomplete, "|"); got != want || p.Complete() != (c.incomplete == nil) {
+			t.Fatalf("%s: incomplete %q want %q", c.in, got, want)
+		}
+	}
+
+	p, _ := ParsePartial([]byte(`{"user":{"id":7,"name":"Bo`))
+	if id, _ := p.Get("user").Get("id").Int(); id != 7 || !p.IsIncomplete("user.name") || p.IsIncomplete("user.id") {
+		t.Fatalf("partial lookup: %s %v", p.Raw(), p.Incomplete)
+	}
+	if _, err := ParsePartial([]byte(`{"a" 1`)); err == nil {
+		t.Fatal("want syntax error")
+	}
+}
@@ -0,0 +1,418 @@
+package zeronode
+
+import (
+	"strconv"
+)
+
+// FixKind Repair 所做修正的类型
+type FixKind uint8
+
+const (
+	FixComment       FixKind = iota + 1 // 删除 // 与 /* */ 注释
+	FixTrailingComma                    // 删除对象/数组末尾多余的逗号
+	FixUnquotedKey                      // 为裸键加引号
+	FixSingleQuote                      // 单引号字符串改为双引号
+	FixLiteral                          // True/False/None/undefined 改为 JSON 字面量
+	FixNonFinite                        // NaN/Infinity 改为 null
+	FixNumber                           // +1、.5、1.、0x1F、前导零等数字写法
+	FixString                           // 字符串中的裸控制字符、续行与非标准转义
+	FixHashComment                      // 删除 # 注释（JSONC / JSON5 均不允许）
+)
+
+var fixKindNames = [...]string{"", "comment", "trailing comma", "unquoted key", "single quote", "literal", "non-finite number", "number", "string", "hash comment"}
+
+func (k FixKind) String() string {
+	if int(k) < len(fixKindNames) && k != 0 {
+		return fixKindNames[k]
+	}
+	return "fix(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Fix 一处修正；Offset 为输入中的字节偏移
+type Fix struct {
+	Offset int
+	Kind   FixKind
+}
+
+func (f Fix) String() string { return f.Kind.String() + " at offset " + strconv.Itoa(f.Offset) }
+
+// Repair 将“近似 JSON”改写为合法 JSON 并返回所做的修正：
+// 单引号字符串、裸键、尾逗号、注释、NaN/Infinity、Python 的 True/False/None、
+// 非标准数字写法以及字符串中的裸控制字符。
+// 输入无需修正时原样返回 b（不拷贝）；无法修复的错误返回 *SyntaxError。
+func Repair(b []byte) ([]byte, []Fix, error) {
+	r := repairer{b: b, out: make([]byte, 0, len(b))}
+	i, err := r.space(0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if i >= len(b) {
+		return nil, nil, &SyntaxError{Offset: i, Msg: "unexpected end of input"}
+	}
+	if i, err = r.value(i); err != nil {
+		return nil, nil, err
+	}
+	if i, err = r.space(i); err != nil {
+		return nil, nil, err
+	}
+	if i < len(b) {
+		return nil, nil, &SyntaxError{Offset: i, Msg: "unexpected data after top-level value"}
+	}
+	if len(r.fixes) == 0 {
+		return b, nil, nil
+	}
+	return r.out, r.fixes, nil
+}
+
+type repairer struct {
+	b     []byte
+	out   []byte
+	fixes []Fix
+}
+
+func (r *repairer) fix(off int, k FixKind) { r.fixes = append(r.fixes, Fix{Offset: off, Kind: k}) }
+
+// space 复制空白，删除注释
+func (r *repairer) space(i int) (int, error) {
+	b := r.b
+	for i < len(b) {
+		switch c := b[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			r.out = append(r.out, c)
+			i++
+		case c == '#' || (c == '/' && i+1 < len(b) && b[i+1] == '/'):
+			if c == '#' {
+				r.fix(i, FixHashComment)
+			} else {
+				r.fix(i, FixComment)
+			}
+			for i < len(b) && b[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(b) && b[i+1] == '*':
+			r.fix(i, FixComment)
+			start := i
+			for i += 2; ; i++ {
+				if i+1 >= len(b) {
+					return i, &SyntaxError{Offset: start, Msg: "unterminated comment"}
+				}
+				if b[i] == '*' && b[i+1] == '/' {
+					i += 2
+					break
+				}
+			}
+		default:
+			return i, nil
+		}
+	}
+	return i, nil
+}
+
+func (r *repairer) value(i int) (int, error) {
+	b := r.b
+	switch c := b[i]; {
+	case c == '{':
+		return r.container(i, '}')
+	case c == '[':
+		return r.container(i, ']')
+	case c == '"' || c == '\'':
+		return r.string(i)
+	case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+		return r.number(i)
+	case isIdentStart(c):
+		return r.ident(i)
+	}
+	return i, invalidChar(b, i)
+}
+
+func (r *repairer) container(i int, closer byte) (int, error) {
+	b := r.b
+	r.out = append(r.out, b[i])
+	i, err := r.space(i + 1)
+	for err == nil {
+		if i >= len(b) {
+			return i, invalidChar(b, i)
+		}
+		if b[i] == closer {
+			r.out = append(r.out, closer)
+			return i + 1, nil
+		}
+		if closer == '}' {
+			if i, err = r.key(i); err != nil {
+				return i, err
+			}
+			if i, err = r.space(i); err != nil {
+				return i, err
+			}
+			if i >= len(b) || b[i] != ':' {
+				return i, invalidChar(b, i)
+			}
+			r.out = append(r.out, ':')
+			if i, err = r.space(i + 1); err != nil {
+				return i, err
+			}
+			if i >= len(b) {
+				return i, invalidChar(b, i)
+			}
+		}
+		if i, err = r.value(i); err != nil {
+			return i, err
+		}
+		if i, err = r.space(i); err != nil {
+			return i, err
+		}
+		if i >= len(b) {
+			return i, invalidChar(b, i)
+		}
+		switch b[i] {
+		case ',':
+			comma, at := len(r.out), i
+			r.out = append(r.out, ',')
+			if i, err = r.space(i + 1); err == nil && i < len(b) && b[i] == closer {
+				r.fix(at, FixTrailingComma)
+				r.out = append(r.out[:comma], r.out[comma+1:]...)
+			}
+		case closer:
+		default:
+			return i, invalidChar(b, i)
+		}
+	}
+	return i, err
+}
+
+func (r *repairer) key(i int) (int, error) {
+	b := r.b
+	if b[i] == '"' || b[i] == '\'' {
+		return r.string(i)
+	}
+	if !isIdentStart(b[i]) {
+		return i, invalidChar(b, i)
+	}
+	r.fix(i, FixUnquotedKey)
+	j := i
+	for j < len(b) && isIdentPart(b[j]) {
+		j++
+	}
+	r.out = append(r.out, '"')
+	r.out = append(r.out, b[i:j]...)
+	r.out = append(r.out, '"')
+	return j, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '$'
+}
+
+func isIdentPart(c byte) bool { return isIdentStart(c) || c >= '0' && c <= '9' }
+
+func (r *repairer) string(i int) (int, error) {
+	b := r.b
+	q := b[i]
+	if q == '\'' {
+		r.fix(i, FixSingleQuote)
+	}
+	r.out = append(r.out, '"')
+	for j := i + 1; j < len(b); {
+		c := b[j]
+		switch {
+		case c == q:
+			r.out = append(r.out, '"')
+			return j + 1, nil
+		case c == '"': // 单引号字符串中的双引号
+			r.out = append(r.out, '\\', '"')
+			j++
+		case c == '\\':
+			if j+1 >= len(b) {
+				return j, &SyntaxError{Offset: j, Msg: "unterminated string"}
+			}
+			n, err := r.escape(j, q)
+			if err != nil {
+				return j, err
+			}
+			j += n
+		case c < 0x20:
+			r.fix(j, FixString)
+			switch c {
+			case '\n':
+				r.out = append(r.out, '\\', 'n')
+			case '\r':
+				r.out = append(r.out, '\\', 'r')
+			case '\t':
+				r.out = append(r.out, '\\', 't')
+			default:
+				r.out = append(r.out, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			}
+			j++
+		default:
+			r.out = append(r.out, c)
+			j++
+		}
+	}
+	return i, &SyntaxError{Offset: i, Msg: "unterminated string"}
+}
+
+const hexDigits = "0123456789abcdef"
+
+// escape 处理 b[j] == '\\' 开始的转义，返回消耗的字节数
+func (r *repairer) escape(j int, q byte) (int, error) {
+	b := r.b
+	switch e := b[j+1]; e {
+	case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+		r.out = append(r.out, '\\', e)
+		return 2, nil
+	case 'u':
+		if j+6 > len(b) || !isHex(b[j+2]) || !isHex(b[j+3]) || !isHex(b[j+4]) || !isHex(b[j+5]) {
+			return 0, &SyntaxError{Offset: j, Msg: "invalid \\u escape"}
+		}
+		r.out = append(r.out, b[j:j+6]...)
+		return 6, nil
+	case 'x': // \xHH
+		if j+4 > len(b) || !isHex(b[j+2]) || !isHex(b[j+3]) {
+			return 0, &SyntaxError{Offset: j, Msg: "invalid \\x escape"}
+		}
+		r.fix(j, FixString)
+		r.out = append(r.out, '\\', 'u', '0', '0', b[j+2], b[j+3])
+		return 4, nil
+	case '\n': // 续行
+		r.fix(j, FixString)
+		return 2, nil
+	case '\r':
+		r.fix(j, FixString)
+		if j+2 < len(b) && b[j+2] == '\n' {
+			return 3, nil
+		}
+		return 2, nil
+	case '\'':
+		r.out = append(r.out, '\'')
+		if q != '\'' { // 仅在双引号字符串中才是非标准转义
+			r.fix(j, FixString)
+		}
+		return 2, nil
+	default:
+		if e < 0x20 {
+			return 0, &SyntaxError{Offset: j, Msg: "invalid escape"}
+		}
+		// 其他转义按字符本身处理
+		r.fix(j, FixString)
+		r.out = append(r.out, e)
+		return 2, nil
+	}
+}
+
+func (r *repairer) number(i int) (int, error) {
+	b := r.b
+	start := i
+	neg := false
+	if b[i] == '+' || b[i] == '-' {
+		neg = b[i] == '-'
+		if !neg {
+			r.fix(i, FixNumber)
+		}
+		i++
+	}
+	if i < len(b) && isIdentStart(b[i]) { // +Infinity / -NaN
+		j := i
+		for j < len(b) && isIdentPart(b[j]) {
+			j++
+		}
+		if s := string(b[i:j]); s == "Infinity" || s == "NaN" || s == "inf" || s == "nan" {
+			r.fix(start, FixNonFinite)
+			r.out = append(r.out, "null"...)
+			return j, nil
+		}
+		return i, invalidChar(b, i)
+	}
+	if neg {
+		r.out = append(r.out, '-')
+	}
+	// 十六进制
+	if i+1 < len(b) && b[i] == '0' && (b[i+1] == 'x' || b[i+1] == 'X') {
+		j := i + 2
+		for j < len(b) && isHex(b[j]) {
+			j++
+		}
+		v, err := strconv.ParseUint(string(b[i+2:j]), 16, 64)
+		if err != nil {
+			return i, &SyntaxError{Offset: i, Msg: "invalid hex number"}
+		}
+		r.fix(start, FixNumber)
+		r.out = strconv.AppendUint(r.out, v, 10)
+		return j, nil
+	}
+	j := i
+	for j < len(b) && b[j] >= '0' && b[j] <= '9' {
+		j++
+	}
+	switch {
+	case j == i: // .5
+		if j >= len(b) || b[j] != '.' {
+			return j, invalidChar(b, j)
+		}
+		r.fix(start, FixNumber)
+		r.out = append(r.out, '0')
+	case b[i] == '0' && j-i > 1: // 007
+		r.fix(start, FixNumber)
+		for i < j-1 && b[i] == '0' {
+			i++
+		}
+		r.out = append(r.out, b[i:j]...)
+	default:
+		r.out = append(r.out, b[i:j]...)
+	}
+	if j < len(b) && b[j] == '.' {
+		k := j + 1
+		for k < len(b) && b[k] >= '0' && b[k] <= '9' {
+			k++
+		}
+		if k == j+1 { // 1.
+			r.fix(j, FixNumber)
+		} else {
+			r.out = append(r.out, b[j:k]...)
+		}
+		j = k
+	}
+	if j < len(b) && (b[j] == 'e' || b[j] == 'E') {
+		k := j + 1
+		if k < len(b) && (b[k] == '+' || b[k] == '-') {
+			k++
+		}
+		d := k
+		for k < len(b) && b[k] >= '0' && b[k] <= '9' {
+			k++
+		}
+		if k == d {
+			return k, invalidChar(b, k)
+		}
+		r.out = append(r.out, b[j:k]...)
+		j = k
+	}
+	return j, nil
+}
+
+func (r *repairer) ident(i int) (int, error) {
+	b := r.b
+	j := i
+	for j < len(b) && isIdentPart(b[j]) {
+		j++
+	}
+	var lit string
+	kind := FixLiteral
+	switch s := string(b[i:j]); s {
+	case "true", "false", "null":
+		r.out = append(r.out, s...)
+		return j, nil
+	case "True", "TRUE":
+		lit = "true"
+	case "False", "FALSE":
+		lit = "false"
+	case "None", "NULL", "Null", "nil", "undefined":
+		lit = "null"
+	case "NaN", "Infinity", "nan", "inf":
+		lit, kind = "null", FixNonFinite
+	default:
+		return i, &SyntaxError{Offset: i, Msg: "invalid literal " + strconv.Quote(s)}
+	}
+	r.fix(i, kind)
+	r.out = append(r.out, lit...)
+	return j, nil
+}
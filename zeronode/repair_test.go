@@ -0,0 +1,59 @@
+package zeronode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepair(t *testing.T) {
+	in := `// header
+{
+  name: 'O\'Brien "x"', # py comment
+  'ok': True, nothing: None, /* block */
+  n: [+1, .5, 2., 0x1F, 007, NaN, -Infinity,],
+  s: "a
+b",
+}`
+	out, fixes, err := Repair([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(out); err != nil {
+		t.Fatalf("%s: %v", out, err)
+	}
+	n := FromBytes(out)
+	if got := n.Get("name").UnescapedString(); got != `O'Brien "x"` {
+		t.Fatalf("name = %q", got)
+	}
+	if ok, _ := n.Get("ok").Bool(); !ok || !n.Get("nothing").IsNull() {
+		t.Fatal("literals not repaired")
+	}
+	var nums []string
+	n.Get("n").ForEachArray(func(_ int, v Node) bool { nums = append(nums, string(v.Raw())); return true })
+	if want := "1 0.5 2 31 7 null null"; strings.Join(nums, " ") != want {
+		t.Fatalf("n = %q", strings.Join(nums, " "))
+	}
+	if n.Get("s").UnescapedString() != "a\nb" {
+		t.Fatalf("s = %q", n.Get("s").Raw())
+	}
+	kinds := map[FixKind]int{}
+	for _, f := range fixes {
+		kinds[f.Kind]++
+	}
+	if kinds[FixComment] != 2 || kinds[FixHashComment] != 1 || kinds[FixTrailingComma] != 2 || kinds[FixUnquotedKey] != 4 || kinds[FixNonFinite] != 2 {
+		t.Fatalf("fixes = %v", fixes)
+	}
+	if fixes[0] != (Fix{Offset: 0, Kind: FixComment}) {
+		t.Fatalf("first fix = %v", fixes[0])
+	}
+
+	valid := []byte(`{"a": [1, 2]}`)
+	if out, fixes, err := Repair(valid); err != nil || len(fixes) != 0 || &out[0] != &valid[0] {
+		t.Fatal("valid input should be returned as is")
+	}
+	for _, bad := range []string{`{a: }`, `{'a': 1`, `[1 2]`, `{a: foo}`, `/* x`} {
+		if _, _, err := Repair([]byte(bad)); err == nil {
+			t.Fatalf("%s: want error", bad)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package zeronode
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SyntaxError JSON 语法错误；Offset 为出错位置在输入中的字节偏移。
+type SyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return "zeronode: " + e.Msg + " at offset " + strconv.Itoa(e.Offset)
+}
+
+// Validate 严格校验 b 是否恰好为一个完整的 JSON 值（前后允许空白）。
+// 与 FromBytes 的宽松定位不同，这里检查字符串转义、数字格式与括号配对；
+// 嵌套超过全局 Limits.MaxDepth 时返回 *LimitError。
+func Validate(b []byte) error {
+	i := skipSpaces(b, 0)
+	if i >= len(b) {
+		return &SyntaxError{Offset: i, Msg: "unexpected end of input"}
+	}
+	end, err := validValue(b, i)
+	if err != nil {
+		return err
+	}
+	if i = skipSpaces(b, end); i < len(b) {
+		return &SyntaxError{Offset: i, Msg: "unexpected data after top-level value"}
+	}
+	return nil
+}
+
+// validValue 校验从 i 开始的单个值，返回值结束后的位置。
+// 嵌套层数受全局 Limits.MaxDepth 约束，避免深层输入耗尽栈。
+func validValue(b []byte, i int) (int, error) {
+	return validNested(b, i, 0, GetLimits().MaxDepth)
+}
+
+func validNested(b []byte, i, depth, maxDepth int) (int, error) {
+	if i >= len(b) {
+		return i, &SyntaxError{Offset: i, Msg: "unexpected end of input"}
+	}
+	switch c := b[i]; {
+	case c == '{' || c == '[':
+		if depth++; maxDepth > 0 && depth > maxDepth {
+			return i, &LimitError{Limit: LimitDepth, Max: maxDepth, Offset: i}
+		}
+		if c == '{' {
+			return validObject(b, i, depth, maxDepth)
+		}
+		return validArray(b, i, depth, maxDepth)
+	case c == '"':
+		return validString(b, i)
+	case c == 't':
+		return validLiteral(b, i, "true")
+	case c == 'f':
+		return validLiteral(b, i, "false")
+	case c == 'n':
+		return validLiteral(b, i, "null")
+	case c == '-' || (c >= '0' && c <= '9'):
+		return validNumber(b, i)
+	default:
+		return i, invalidChar(b, i)
+	}
+}
+
+func invalidChar(b []byte, i int) error {
+	if i >= len(b) {
+		return &SyntaxError{Offset: i, Msg: "unexpected end of input"}
+	}
+	return &SyntaxError{Offset: i, Msg: fmt.Sprintf("invalid character %q", b[i])}
+}
+
+func validLiteral(b []byte, i int, lit string) (int, error) {
+	for j := 0; j < len(lit); j++ {
+		if i+j >= len(b) || b[i+j] != lit[j] {
+			return i + j, invalidChar(b, i+j)
+		}
+	}
+	return i + len(lit), nil
+}
+
+func validString(b []byte, i int) (int, error) {
+	i++ // 跳过 '"'
+	for i < len(b) {
+		c := b[i]
+		switch {
+		case c == '"':
+			return i + 1, nil
+		case c < 0x20:
+			return i, &SyntaxError{Offset: i, Msg: "control character in string"}
+		case c == '\\':
+			i++
+			if i >= len(b) {
+				return i, invalidChar(b, i)
+			}
+			switch b[i] {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+				i++
+			case 'u':
+				for j := 1; j <= 4; j++ {
+					if i+j >= len(b) || !isHex(b[i+j]) {
+						return i + j, &SyntaxError{Offset: i + j, Msg: "invalid \\u escape"}
+					}
+				}
+				i += 5
+			default:
+				return i, &SyntaxError{Offset: i, Msg: "invalid escape"}
+			}
+		default:
+			i++
+		}
+	}
+	return i, &SyntaxError{Offset: i, Msg: "unterminated string"}
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c|0x20 >= 'a' && c|0x20 <= 'f')
+}
+
+func validNumber(b []byte, i int) (int, error) {
+	if b[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(b) && b[i] == '0':
+		i++
+	case i < len(b) && b[i] >= '1' && b[i] <= '9':
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+	default:
+		return i, invalidChar(b, i)
+	}
+	if i < len(b) && b[i] == '.' {
+		i++
+		if i >= len(b) || b[i] < '0' || b[i] > '9' {
+			return i, invalidChar(b, i)
+		}
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+	}
+	if i < len(b) && (b[i] == 'e' || b[i] == 'E') {
+		i++
+		if i < len(b) && (b[i] == '+' || b[i] == '-') {
+			i++
+		}
+		if i >= len(b) || b[i] < '0' || b[i] > '9' {
+			return i, invalidChar(b, i)
+		}
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+	}
+	return i, nil
+}
+
+func validObject(b []byte, i, depth, maxDepth int) (int, error) {
+	i = skipSpaces(b, i+1)
+	if i < len(b) && b[i] == '}' {
+		return i + 1, nil
+	}
+	for {
+		if i >= len(b) || b[i] != '"' {
+			return i, invalidChar(b, i)
+		}
+		var err error
+		if i, err = validString(b, i); err != nil {
+			return i, err
+		}
+		i = skipSpaces(b, i)
+		if i >= len(b) || b[i] != ':' {
+			return i, invalidChar(b, i)
+		}
+		if i, err = validNested(b, skipSpaces(b, i+1), depth, maxDepth); err != nil {
+			return i, err
+		}
+		i = skipSpaces(b, i)
+		if i >= len(b) {
+			return i, invalidChar(b, i)
+		}
+		switch b[i] {
+		case ',':
+			i = skipSpaces(b, i+1)
+		case '}':
+			return i + 1, nil
+		default:
+			return i, invalidChar(b, i)
+		}
+	}
+}
+
+func validArray(b []byte, i, depth, maxDepth int) (int, error) {
+	i = skipSpaces(b, i+1)
+	if i < len(b) && b[i] == ']' {
+		return i + 1, nil
+	}
+	for {
+		var err error
+		if i, err = validNested(b, i, depth, maxDepth); err != nil {
+			return i, err
+		}
+		i = skipSpaces(b, i)
+		if i >= len(b) {
+			return i, invalidChar(b, i)
+		}
+		switch b[i] {
+		case ',':
+			i = skipSpaces(b, i+1)
+		case ']':
+			return i + 1, nil
+		default:
+			return i, invalidChar(b, i)
+		}
+	}
+}